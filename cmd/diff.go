@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/IgorBayerl/nanovision/internal/reporter/reporter_rawjson"
+	"github.com/IgorBayerl/nanovision/internal/reporter/treediff"
+)
+
+// runDiff implements "nanovision diff", which compares the RawJson reports of
+// two earlier runs, e.g. of two release branches, without parsing any
+// coverage report again.
+func runDiff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	basePath := flags.String("base", "", "RawJson report of the run to compare against")
+	headPath := flags.String("head", "", "RawJson report of the run to compare")
+	outputDir := flags.String("output", "coverage-diff", "Output directory for the diff report")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s diff:\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *basePath == "" || *headPath == "" {
+		flags.Usage()
+		return errors.New("both -base and -head must be specified")
+	}
+
+	base, err := reporter_rawjson.LoadSummaryTree(*basePath)
+	if err != nil {
+		return fmt.Errorf("failed to load base: %w", err)
+	}
+	head, err := reporter_rawjson.LoadSummaryTree(*headPath)
+	if err != nil {
+		return fmt.Errorf("failed to load head: %w", err)
+	}
+	head.Baseline = base
+
+	if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return treediff.NewDiffReportBuilder(*outputDir, slog.Default()).CreateReport(head)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,19 +23,42 @@ import (
 	"github.com/IgorBayerl/nanovision/internal/aggregator"
 	"github.com/IgorBayerl/nanovision/internal/config"
 	"github.com/IgorBayerl/nanovision/internal/enricher"
+	"github.com/IgorBayerl/nanovision/internal/history"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_clover"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_cobertura"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_coveragepy"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_gcov"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_gcovjson"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_gocover"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_istanbul"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_jacoco"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_lcov"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_llvmcov"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_opencover"
+	"github.com/IgorBayerl/nanovision/internal/patch"
+	"github.com/IgorBayerl/nanovision/internal/qualitygate"
+	"github.com/IgorBayerl/nanovision/internal/reporter/badge"
+	"github.com/IgorBayerl/nanovision/internal/reporter/cobertura"
+	"github.com/IgorBayerl/nanovision/internal/reporter/diagnostics"
 	"github.com/IgorBayerl/nanovision/internal/reporter/htmlreact"
 	"github.com/IgorBayerl/nanovision/internal/reporter/lcov"
+	"github.com/IgorBayerl/nanovision/internal/reporter/markdown"
 	"github.com/IgorBayerl/nanovision/internal/reporter/reporter_rawjson"
+	"github.com/IgorBayerl/nanovision/internal/reporter/sonarqube"
 	"github.com/IgorBayerl/nanovision/internal/reporter/textsummary"
+	"github.com/IgorBayerl/nanovision/internal/reporter/treediff"
 	"github.com/IgorBayerl/nanovision/internal/tree"
+	"github.com/IgorBayerl/nanovision/internal/utils"
 	"github.com/IgorBayerl/nanovision/logging"
 )
 
+// exitCodeQualityGateFailed is the exit code of a run whose reports were
+// generated but whose coverage is below a configured minimum. Any other
+// failure exits with 1, so CI can tell a coverage drop from a broken run.
+const exitCodeQualityGateFailed = 2
+
 func parseAndBindFlags() *config.RawConfigInput {
 	rawInput := &config.RawConfigInput{}
 
@@ -48,9 +73,31 @@ func parseAndBindFlags() *config.RawConfigInput {
 	flag.StringVar(&rawInput.LogFormat, "logformat", "text", "Log output format: text (default) or json")
 	flag.StringVar(&rawInput.Verbosity, "verbosity", "Info", "Logging level: Verbose, Info, Warning, Error, Off")
 	flag.BoolVar(&rawInput.Verbose, "verbose", false, "Shortcut for Verbose logging (overridden by -verbosity)")
+	flag.Func("minimumlinecoverage", "Fail with exit code 2 if line coverage is below this percentage", optionalFloat(&rawInput.MinimumLineCoverage))
+	flag.Func("minimumbranchcoverage", "Fail with exit code 2 if branch coverage is below this percentage", optionalFloat(&rawInput.MinimumBranchCoverage))
+	flag.Func("minimummethodcoverage", "Fail with exit code 2 if method coverage is below this percentage", optionalFloat(&rawInput.MinimumMethodCoverage))
+	flag.StringVar(&rawInput.PatchDiff, "patchdiff", "", "Unified diff file to compute patch coverage for")
+	flag.StringVar(&rawInput.PatchBase, "patchbase", "", "Git ref to compute patch coverage against, using 'git diff <ref>...HEAD'")
+	flag.StringVar(&rawInput.Baseline, "baseline", "", "RawJson report of an earlier run to show coverage changes against")
+	flag.StringVar(&rawInput.HistoryDir, "historydir", "", "Directory to record the coverage of each run in and read the trend from")
+	flag.StringVar(&rawInput.Commit, "commit", "", "Optional commit id recorded with the history snapshot")
+	flag.StringVar(&rawInput.MarkdownFlavor, "markdownflavor", "", "Platform the Markdown report is posted to: github (default) or gitlab")
 	return rawInput
 }
 
+// optionalFloat parses a float flag into *target, which stays nil when the
+// flag is not given. This tells an explicit 0 apart from a missing flag.
+func optionalFloat(target **float64) func(string) error {
+	return func(value string) error {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		*target = &parsed
+		return nil
+	}
+}
+
 func buildLogger(appConfig *config.AppConfig) (io.Closer, error) {
 	cfg := logging.Config{
 		Verbosity: appConfig.VerbosityLevel,
@@ -83,6 +130,7 @@ func parseReportFiles(logger *slog.Logger, appConfig *config.AppConfig, inputPai
 				SrcDirs:    []string{pair.SourceDir},
 				FileFilter: appConfig.FileFilterInstance,
 				Log:        logger,
+				Mapper:     appConfig.PathMapper,
 			}
 
 			parserInstance, err := parserFactory.FindParserForFile(absFile)
@@ -104,6 +152,7 @@ func parseReportFiles(logger *slog.Logger, appConfig *config.AppConfig, inputPai
 
 			result.SourceDirectory = pair.SourceDir
 			result.ReportPattern = pair.ReportPattern
+			result.ReportFile = absFile
 			parserResults = append(parserResults, result)
 			totalFilesParsed++
 			logger.Info("Successfully parsed file", "file", absFile)
@@ -119,7 +168,49 @@ func parseReportFiles(logger *slog.Logger, appConfig *config.AppConfig, inputPai
 	return parserResults, nil
 }
 
-func generateReports(appConfig *config.AppConfig, summaryTree *model.SummaryTree) error {
+// computePatchCoverage intersects the lines changed by the configured diff
+// file, or by "git diff <base>...HEAD", with the coverage of the tree.
+func computePatchCoverage(appConfig *config.AppConfig, summaryTree *model.SummaryTree) (*model.PatchCoverage, error) {
+	var diff []byte
+	var base string
+	var err error
+	if appConfig.PatchDiff != "" {
+		base = appConfig.PatchDiff
+		diff, err = os.ReadFile(appConfig.PatchDiff)
+	} else {
+		base = appConfig.PatchBase + "...HEAD"
+		diff, err = patch.GitDiff(appConfig.ProjectRoot, appConfig.PatchBase)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load diff for patch coverage: %w", err)
+	}
+
+	changes, err := patch.ParseUnifiedDiff(bytes.NewReader(diff))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff '%s': %w", base, err)
+	}
+	return patch.Compute(summaryTree, changes, base), nil
+}
+
+// recordHistory saves the current run to the history directory and prunes
+// the snapshots beyond the configured count and age.
+func recordHistory(appConfig *config.AppConfig, store *history.Store, summaryTree *model.SummaryTree) error {
+	now := time.Now()
+	snapshot := history.NewSnapshot(summaryTree, appConfig.Tag, appConfig.Commit, now)
+	if err := store.Save(snapshot); err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+
+	maxAge := time.Duration(appConfig.HistoryMaxAgeDays) * 24 * time.Hour
+	removed, err := store.Prune(appConfig.HistoryMaxCount, maxAge, now)
+	if err != nil {
+		slog.Default().Warn("Some history snapshots could not be pruned.", "error", err)
+	}
+	slog.Default().Info("History recorded.", "directory", appConfig.HistoryDir, "tag", appConfig.Tag, "pruned", removed)
+	return nil
+}
+
+func generateReports(appConfig *config.AppConfig, summaryTree *model.SummaryTree, fileReader filereader.Reader) error {
 	logger := slog.Default()
 	outputDir := appConfig.OutputDir
 
@@ -134,13 +225,28 @@ func generateReports(appConfig *config.AppConfig, summaryTree *model.SummaryTree
 		var err error
 		switch trimmedType {
 		case "TextSummary":
-			err = textsummary.NewTextReportBuilder(outputDir, logger).CreateReport(summaryTree)
+			err = textsummary.NewTextReportBuilder(outputDir, appConfig.RiskThresholds, logger).CreateReport(summaryTree)
 		case "Html":
-			err = htmlreact.NewHtmlReactReportBuilder(outputDir, logger).CreateReport(summaryTree)
+			err = htmlreact.NewHtmlReactReportBuilder(outputDir, fileReader, appConfig.RiskThresholds, logger).CreateReport(summaryTree)
 		case "Lcov":
 			err = lcov.NewLcovReportBuilder(outputDir).CreateReport(summaryTree)
 		case "RawJson":
 			err = reporter_rawjson.NewRawJsonReportBuilder(outputDir).CreateReport(summaryTree)
+		case "Diagnostics":
+			err = diagnostics.NewDiagnosticsReportBuilder(outputDir, logger).CreateReport(summaryTree)
+		case "Markdown":
+			flavor := markdown.Flavor(appConfig.MarkdownFlavor)
+			if err = flavor.Validate(); err == nil {
+				err = markdown.NewMarkdownReportBuilder(outputDir, flavor, appConfig.RiskThresholds, logger).CreateReport(summaryTree)
+			}
+		case "Diff":
+			err = treediff.NewDiffReportBuilder(outputDir, logger).CreateReport(summaryTree)
+		case "Cobertura":
+			err = cobertura.NewCoberturaReportBuilder(outputDir, appConfig.ProjectRoot, logger).CreateReport(summaryTree)
+		case "SonarQube":
+			err = sonarqube.NewSonarQubeReportBuilder(outputDir, logger).CreateReport(summaryTree)
+		case "Badge":
+			err = badge.NewBadgeReportBuilder(outputDir, appConfig.RiskThresholds, appConfig.BadgeDirFilter, logger).CreateReport(summaryTree)
 		}
 		if err != nil {
 			return fmt.Errorf("failed to generate '%s' report: %w", trimmedType, err)
@@ -164,24 +270,41 @@ func generateReports(appConfig *config.AppConfig, summaryTree *model.SummaryTree
 //   - Parse: Reads the different coverage report formats into a standard structure.
 //   - Build: Combines data from all parsed reports into a single project tree.
 //   - Enrich: Gathers extra details from the source code, like method complexity.
+//   - Patch: Optionally computes the coverage of the lines changed by a diff.
+//   - Baseline: Optionally loads an earlier run to compare the coverage with.
+//   - History: Optionally loads the snapshots of earlier runs for trends, and
+//     records the current run once the reports are written.
+//   - Gate: Checks the coverage of the project and of the configured paths
+//     against their minimums.
 //   - Report: Generates the final output files, such as the HTML and text summaries.
+//     A failed gate is returned as a *qualitygate.FailedError once they are written.
 func executePipeline(appConfig *config.AppConfig) error {
 	logger := slog.Default()
 	logger.Info("Executing report generation pipeline...")
 
-	prodFileReader := filereader.NewDefaultReader()
+	// A single resolver is shared by every stage, so each source directory is
+	// indexed at most once and every report path is resolved only once.
+	sourceResolver := utils.NewSourceResolver(filereader.NewDefaultReader(), logger)
 	parserFactory := parsers.NewParserFactory(
-		parser_cobertura.NewCoberturaParser(prodFileReader),
-		parser_gocover.NewGoCoverParser(prodFileReader),
-		parser_gcov.NewGCovParser(prodFileReader),
+		parser_cobertura.NewCoberturaParser(sourceResolver),
+		parser_gocover.NewGoCoverParser(sourceResolver),
+		parser_gcov.NewGCovParser(sourceResolver),
+		parser_gcovjson.NewGCovJSONParser(sourceResolver),
+		parser_opencover.NewOpenCoverParser(sourceResolver),
+		parser_jacoco.NewJaCoCoParser(sourceResolver),
+		parser_lcov.NewLcovParser(sourceResolver),
+		parser_istanbul.NewIstanbulParser(sourceResolver),
+		parser_coveragepy.NewCoveragePyParser(sourceResolver),
+		parser_llvmcov.NewLlvmCovParser(sourceResolver),
+		parser_clover.NewCloverParser(sourceResolver),
 	)
-	treeBuilder := tree.NewBuilder(appConfig.ProjectRoot, appConfig.FileFilterInstance)
+	treeBuilder := tree.NewBuilder(appConfig.ProjectRoot, appConfig.FileFilterInstance, sourceResolver)
 
 	allAnalyzers := []analyzer.Analyzer{
 		golang.New(),
 		cpp.New(),
 	}
-	treeEnricher := enricher.New(allAnalyzers, prodFileReader, logger)
+	treeEnricher := enricher.New(allAnalyzers, sourceResolver, logger)
 
 	if len(appConfig.InputPairs) == 0 {
 		return fmt.Errorf("no valid report pattern and source directory pairs were provided")
@@ -207,8 +330,62 @@ func executePipeline(appConfig *config.AppConfig) error {
 
 	aggregator.AggregateMetricsAfterEnrichment(summaryTree)
 
+	if appConfig.PatchDiff != "" || appConfig.PatchBase != "" {
+		logger.Info("Computing patch coverage...")
+		summaryTree.Patch, err = computePatchCoverage(appConfig, summaryTree)
+		if err != nil {
+			return err
+		}
+		logger.Info("Patch coverage computed.", "base", summaryTree.Patch.Base, "files", len(summaryTree.Patch.Files), "coverableLines", summaryTree.Patch.Metrics.LinesValid)
+	}
+
+	if appConfig.Baseline != "" {
+		summaryTree.Baseline, err = reporter_rawjson.LoadSummaryTree(appConfig.Baseline)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline: %w", err)
+		}
+		logger.Info("Baseline loaded.", "path", appConfig.Baseline)
+	}
+
+	var historyStore *history.Store
+	if appConfig.HistoryDir != "" {
+		historyStore = history.NewStore(appConfig.HistoryDir)
+		snapshots, err := historyStore.Load()
+		if err != nil {
+			logger.Warn("Some history snapshots could not be read and are ignored.", "error", err)
+		}
+		summaryTree.History = history.ExcludeTag(snapshots, appConfig.Tag)
+		logger.Info("History loaded.", "directory", appConfig.HistoryDir, "snapshots", len(summaryTree.History))
+	}
+
+	projectMetrics := appConfig.PathThresholdRules.ExcludeIgnored(summaryTree.Metrics, summaryTree.Root)
+	gateResults := qualitygate.Evaluate(projectMetrics, appConfig.QualityGates())
+	gateResults = append(gateResults, appConfig.PathThresholdRules.EvaluateTree(summaryTree.Root)...)
+	for _, result := range gateResults {
+		if result.Passed {
+			logger.Debug("Quality gate passed", "gate", result.String())
+		} else {
+			logger.Warn("Quality gate failed", "gate", result.String())
+		}
+	}
+	summaryTree.GateResults = gateResults
+
+	// Reports are written even when a gate fails, so the drop can be inspected.
 	logger.Info("Executing REPORT stage...")
-	return generateReports(appConfig, summaryTree)
+	if err := generateReports(appConfig, summaryTree, sourceResolver); err != nil {
+		return err
+	}
+
+	if historyStore != nil {
+		if err := recordHistory(appConfig, historyStore, summaryTree); err != nil {
+			return err
+		}
+	}
+
+	if failures := qualitygate.Failed(gateResults); len(failures) > 0 {
+		return &qualitygate.FailedError{Failures: failures}
+	}
+	return nil
 }
 
 func determineProjectRoot(configPath string) (string, error) {
@@ -230,6 +407,14 @@ func determineProjectRoot(configPath string) (string, error) {
 
 func main() {
 	start := time.Now()
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		if err := runDiff(os.Args[2:]); err != nil {
+			slog.Error("Failed to compare coverage", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
 		flag.PrintDefaults()
@@ -272,6 +457,14 @@ func main() {
 	}
 
 	if err := executePipeline(appConfig); err != nil {
+		var gateErr *qualitygate.FailedError
+		if errors.As(err, &gateErr) {
+			fmt.Fprintln(os.Stderr, "Quality gates failed:")
+			for _, failure := range gateErr.Failures {
+				fmt.Fprintf(os.Stderr, "  %s\n", failure)
+			}
+			os.Exit(exitCodeQualityGateFailed)
+		}
 		slog.Error("An error occurred during report generation", "error", err)
 		os.Exit(1)
 	}
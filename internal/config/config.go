@@ -7,7 +7,10 @@ import (
 	"strings"
 
 	"github.com/IgorBayerl/nanovision/filtering"
+	"github.com/IgorBayerl/nanovision/internal/qualitygate"
+	"github.com/IgorBayerl/nanovision/internal/risk"
 	"github.com/IgorBayerl/nanovision/logging"
+	"github.com/IgorBayerl/nanovision/pathmapping"
 	"gopkg.in/yaml.v3"
 )
 
@@ -28,6 +31,22 @@ type RawConfigInput struct {
 	LogFormat      string
 	Verbosity      string
 	Verbose        bool
+
+	// The minimums are nil when their flag was not given, so that an explicit
+	// 0 can turn off a minimum set in the config file.
+	MinimumLineCoverage   *float64
+	MinimumBranchCoverage *float64
+	MinimumMethodCoverage *float64
+
+	PatchDiff string
+	PatchBase string
+
+	Baseline string
+
+	HistoryDir string
+	Commit     string
+
+	MarkdownFlavor string
 }
 
 type AppConfig struct {
@@ -44,7 +63,53 @@ type AppConfig struct {
 	IgnoreFiles    []string `yaml:"ignore_files"`
 	ProjectRoot    string   `yaml:"-"`
 
+	PathMappings []pathmapping.Rule `yaml:"path_mappings"`
+
+	// Quality gates, as percentages (0-100). A run whose overall coverage is
+	// below a minimum fails with a distinct exit code. Zero disables a gate.
+	MinimumLineCoverage   float64 `yaml:"minimum_line_coverage"`
+	MinimumBranchCoverage float64 `yaml:"minimum_branch_coverage"`
+	MinimumMethodCoverage float64 `yaml:"minimum_method_coverage"`
+
+	// PathThresholds add minimums for the directories and files matching a
+	// pattern, or exempt them from the path minimums.
+	PathThresholds []qualitygate.PathRule `yaml:"path_thresholds"`
+
+	// RiskThresholds are the safe/warning/danger bands the reports classify
+	// coverage and complexity with. Metrics that are not configured keep
+	// their defaults.
+	RiskThresholds risk.Thresholds `yaml:"risk_thresholds"`
+
+	// Patch coverage is computed for the lines changed by a unified diff,
+	// read from PatchDiff or from "git diff <PatchBase>...HEAD".
+	PatchDiff string `yaml:"patch_diff"`
+	PatchBase string `yaml:"patch_base"`
+
+	// Baseline is the RawJson report of an earlier run, e.g. on the target
+	// branch, that the Markdown and Diff reports show coverage changes against.
+	Baseline string `yaml:"baseline"`
+
+	// Every run is recorded as a snapshot in HistoryDir, which is pruned to
+	// the newest HistoryMaxCount snapshots no older than HistoryMaxAgeDays.
+	// Zero disables a limit. Commit is recorded with the snapshot.
+	HistoryDir        string `yaml:"history_dir"`
+	HistoryMaxCount   int    `yaml:"history_max_count"`
+	HistoryMaxAgeDays int    `yaml:"history_max_age_days"`
+	Commit            string `yaml:"commit"`
+
+	// MarkdownFlavor is the platform the Markdown report is posted to,
+	// "github" or "gitlab", which differ in the emoji they know. The Markdown
+	// report checks it, see markdown.Flavor.
+	MarkdownFlavor string `yaml:"markdown_flavor"`
+
+	// BadgeDirectories selects the directories that get coverage badges of
+	// their own, using the wildcard syntax of the file filters.
+	BadgeDirectories []string `yaml:"badge_directories"`
+
 	FileFilterInstance filtering.IFilter
+	PathMapper         *pathmapping.Mapper
+	PathThresholdRules *qualitygate.PathRules
+	BadgeDirFilter     filtering.IFilter
 	VerbosityLevel     logging.VerbosityLevel
 	InputPairs         []ReportInputPair
 }
@@ -113,6 +178,10 @@ func GetDefaultConfig() *AppConfig {
 		Title:       "Coverage Report",
 		LogFormat:   "text",
 		Verbosity:   "Info",
+
+		RiskThresholds:  risk.DefaultThresholds(),
+		HistoryMaxCount: 100,
+		MarkdownFlavor:  "github",
 	}
 }
 
@@ -151,6 +220,33 @@ func (c *AppConfig) mergeCliOverrides(cli RawConfigInput) {
 	if cli.Verbose {
 		c.Verbosity = "Verbose"
 	}
+	if cli.MinimumLineCoverage != nil {
+		c.MinimumLineCoverage = *cli.MinimumLineCoverage
+	}
+	if cli.MinimumBranchCoverage != nil {
+		c.MinimumBranchCoverage = *cli.MinimumBranchCoverage
+	}
+	if cli.MinimumMethodCoverage != nil {
+		c.MinimumMethodCoverage = *cli.MinimumMethodCoverage
+	}
+	if cli.PatchDiff != "" {
+		c.PatchDiff = cli.PatchDiff
+	}
+	if cli.PatchBase != "" {
+		c.PatchBase = cli.PatchBase
+	}
+	if cli.Baseline != "" {
+		c.Baseline = cli.Baseline
+	}
+	if cli.HistoryDir != "" {
+		c.HistoryDir = cli.HistoryDir
+	}
+	if cli.Commit != "" {
+		c.Commit = cli.Commit
+	}
+	if cli.MarkdownFlavor != "" {
+		c.MarkdownFlavor = cli.MarkdownFlavor
+	}
 }
 
 // validate checks the final configuration for logical errors.
@@ -171,9 +267,40 @@ func (c *AppConfig) validate() error {
 	if _, err := logging.ParseVerbosity(c.Verbosity); err != nil {
 		return fmt.Errorf("invalid verbosity level '%s'", c.Verbosity)
 	}
+	minimums := []struct {
+		name  string
+		value float64
+	}{
+		{"minimum_line_coverage", c.MinimumLineCoverage},
+		{"minimum_branch_coverage", c.MinimumBranchCoverage},
+		{"minimum_method_coverage", c.MinimumMethodCoverage},
+	}
+	for _, minimum := range minimums {
+		if minimum.value < 0 || minimum.value > 100 {
+			return fmt.Errorf("configuration error: %s must be between 0 and 100, got %g", minimum.name, minimum.value)
+		}
+	}
+	if c.PatchDiff != "" && c.PatchBase != "" {
+		return errors.New("configuration error: patch_diff and patch_base cannot be used together")
+	}
+	if c.HistoryMaxCount < 0 || c.HistoryMaxAgeDays < 0 {
+		return errors.New("configuration error: history_max_count and history_max_age_days must not be negative")
+	}
+	if err := c.RiskThresholds.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
 	return nil
 }
 
+// QualityGates returns the configured coverage minimums.
+func (c *AppConfig) QualityGates() qualitygate.Thresholds {
+	return qualitygate.Thresholds{
+		LineCoverage:   c.MinimumLineCoverage,
+		BranchCoverage: c.MinimumBranchCoverage,
+		MethodCoverage: c.MinimumMethodCoverage,
+	}
+}
+
 // computeDerivedFields processes raw config values into usable internal fields.
 func (c *AppConfig) computeDerivedFields() error {
 	allFilters := c.FileFilters
@@ -187,6 +314,30 @@ func (c *AppConfig) computeDerivedFields() error {
 	}
 	c.FileFilterInstance = filter
 
+	mapper, err := pathmapping.New(c.PathMappings)
+	if err != nil {
+		return fmt.Errorf("failed to initialize path mappings: %w", err)
+	}
+	c.PathMapper = mapper
+
+	pathRules, err := qualitygate.NewPathRules(c.PathThresholds)
+	if err != nil {
+		return fmt.Errorf("failed to initialize path thresholds: %w", err)
+	}
+	c.PathThresholdRules = pathRules
+
+	if len(c.BadgeDirectories) > 0 {
+		badgePatterns := make([]string, 0, len(c.BadgeDirectories))
+		for _, pattern := range c.BadgeDirectories {
+			badgePatterns = append(badgePatterns, "+"+strings.TrimSpace(pattern))
+		}
+		badgeFilter, err := filtering.NewDefaultFilter(badgePatterns, true)
+		if err != nil {
+			return fmt.Errorf("failed to initialize badge directories: %w", err)
+		}
+		c.BadgeDirFilter = badgeFilter
+	}
+
 	c.VerbosityLevel, _ = logging.ParseVerbosity(c.Verbosity)
 
 	c.InputPairs = resolveInputPairs(c.ReportPatterns, c.SourceDirs)
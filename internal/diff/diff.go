@@ -0,0 +1,126 @@
+// Package diff compares the coverage of two runs, so regressions between
+// branches or releases can be reviewed without rerunning either test suite.
+//
+// Either run may be freshly built or loaded from the RawJson report of an
+// earlier run. Directories and files are matched by their project-relative
+// paths, so both runs should use the same project root.
+package diff
+
+import (
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+)
+
+// Compare returns the difference between the base and the head tree, with
+// every directory and file of either tree.
+func Compare(base, head *model.SummaryTree) *model.TreeDiff {
+	result := &model.TreeDiff{
+		Project: nodeDiff("", base.Metrics, head.Metrics, true, true),
+	}
+
+	baseDirs, headDirs := directoriesByPath(base.Root), directoriesByPath(head.Root)
+	for _, path := range unionKeys(baseDirs, headDirs) {
+		baseDir, inBase := baseDirs[path]
+		headDir, inHead := headDirs[path]
+		var baseMetrics, headMetrics model.CoverageMetrics
+		if inBase {
+			baseMetrics = baseDir.Metrics
+		}
+		if inHead {
+			headMetrics = headDir.Metrics
+		}
+		result.Directories = append(result.Directories, nodeDiff(path, baseMetrics, headMetrics, inBase, inHead))
+	}
+
+	baseFiles, headFiles := base.FilesByPath(), head.FilesByPath()
+	for _, path := range unionKeys(baseFiles, headFiles) {
+		baseFile, inBase := baseFiles[path]
+		headFile, inHead := headFiles[path]
+		result.Files = append(result.Files, fileDiff(path, baseFile, headFile, inBase, inHead))
+	}
+	return result
+}
+
+func fileDiff(path string, baseFile, headFile *model.FileNode, inBase, inHead bool) model.FileDiff {
+	var baseMetrics, headMetrics model.CoverageMetrics
+	if inBase {
+		baseMetrics = baseFile.Metrics
+	}
+	if inHead {
+		headMetrics = headFile.Metrics
+	}
+	result := model.FileDiff{NodeDiff: nodeDiff(path, baseMetrics, headMetrics, inBase, inHead)}
+	if !inBase || !inHead {
+		return result
+	}
+
+	// Lines are matched by number only, so a line is compared only when it is
+	// coverable in both runs. Lines added by the head, or shifted onto code
+	// that was not coverable before, would otherwise show up as regressions.
+	for lineNumber, line := range headFile.Lines {
+		baseLine, ok := baseFile.Lines[lineNumber]
+		if line.Hits < 0 || !ok || baseLine.Hits < 0 {
+			continue
+		}
+		switch {
+		case line.Hits > 0 && baseLine.Hits == 0:
+			result.NewlyCovered = append(result.NewlyCovered, lineNumber)
+		case line.Hits == 0 && baseLine.Hits > 0:
+			result.NewlyUncovered = append(result.NewlyUncovered, lineNumber)
+		}
+	}
+	sort.Ints(result.NewlyCovered)
+	sort.Ints(result.NewlyUncovered)
+
+	if len(result.NewlyCovered) > 0 || len(result.NewlyUncovered) > 0 {
+		result.Status = model.DiffChanged
+	}
+	return result
+}
+
+func nodeDiff(path string, base, head model.CoverageMetrics, inBase, inHead bool) model.NodeDiff {
+	result := model.NodeDiff{Path: path, Base: base, Head: head}
+	switch {
+	case !inBase:
+		result.Status = model.DiffAdded
+	case !inHead:
+		result.Status = model.DiffRemoved
+	case base != head:
+		result.Status = model.DiffChanged
+	default:
+		result.Status = model.DiffUnchanged
+	}
+	return result
+}
+
+func directoriesByPath(root *model.DirNode) map[string]*model.DirNode {
+	dirs := make(map[string]*model.DirNode)
+	var walk func(dir *model.DirNode)
+	walk = func(dir *model.DirNode) {
+		if dir == nil {
+			return
+		}
+		for _, subdir := range dir.Subdirs {
+			dirs[subdir.Path] = subdir
+			walk(subdir)
+		}
+	}
+	walk(root)
+	return dirs
+}
+
+// unionKeys returns the keys of both maps, sorted.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
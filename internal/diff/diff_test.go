@@ -0,0 +1,82 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/diff"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findFile(t *testing.T, result *model.TreeDiff, path string) model.FileDiff {
+	t.Helper()
+	for _, file := range result.Files {
+		if file.Path == path {
+			return file
+		}
+	}
+	require.Failf(t, "file not found", "no diff for %s", path)
+	return model.FileDiff{}
+}
+
+func TestCompare(t *testing.T) {
+	base := testutil.NewTree(
+		testutil.NewFile("pkg/same.go", 1, 0),
+		testutil.NewFile("pkg/changed.go", 1, 0, 1, -1),
+		testutil.NewFile("pkg/removed.go", 1),
+	)
+	head := testutil.NewTree(
+		testutil.NewFile("pkg/same.go", 1, 0),
+		testutil.NewFile("pkg/changed.go", 0, 1, 1, 0, 2),
+		testutil.NewFile("pkg/added.go", 0),
+	)
+
+	result := diff.Compare(base, head)
+
+	assert.Equal(t, model.DiffChanged, result.Project.Status)
+	assert.Equal(t, base.Metrics, result.Project.Base)
+	assert.Equal(t, head.Metrics, result.Project.Head)
+
+	require.Len(t, result.Directories, 1)
+	assert.Equal(t, "pkg", result.Directories[0].Path)
+	assert.Equal(t, model.DiffChanged, result.Directories[0].Status)
+
+	var paths []string
+	for _, file := range result.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.Equal(t, []string{"pkg/added.go", "pkg/changed.go", "pkg/removed.go", "pkg/same.go"}, paths, "Files should be ordered by path")
+
+	added := findFile(t, result, "pkg/added.go")
+	assert.Equal(t, model.DiffAdded, added.Status)
+	assert.Equal(t, model.CoverageMetrics{}, added.Base)
+	assert.Empty(t, added.NewlyUncovered, "Added files list no lines")
+
+	removed := findFile(t, result, "pkg/removed.go")
+	assert.Equal(t, model.DiffRemoved, removed.Status)
+	assert.Equal(t, model.CoverageMetrics{}, removed.Head)
+
+	changed := findFile(t, result, "pkg/changed.go")
+	assert.Equal(t, model.DiffChanged, changed.Status)
+	assert.Equal(t, []int{2}, changed.NewlyCovered, "Line 5 is not in the base, so it is not compared")
+	assert.Equal(t, []int{1}, changed.NewlyUncovered, "Line 4 was not coverable in the base, so it is not a regression")
+
+	same := findFile(t, result, "pkg/same.go")
+	assert.Equal(t, model.DiffUnchanged, same.Status)
+	assert.Empty(t, same.NewlyCovered)
+	assert.Empty(t, same.NewlyUncovered)
+}
+
+func TestCompare_LinesMovedWithEqualTotals(t *testing.T) {
+	base := testutil.NewTree(testutil.NewFile("pkg/a.go", 1, 0))
+	head := testutil.NewTree(testutil.NewFile("pkg/a.go", 0, 1))
+
+	result := diff.Compare(base, head)
+
+	file := findFile(t, result, "pkg/a.go")
+	assert.Equal(t, model.DiffChanged, file.Status, "A file with equal totals but different lines has changed")
+	assert.Equal(t, []int{2}, file.NewlyCovered)
+	assert.Equal(t, []int{1}, file.NewlyUncovered)
+}
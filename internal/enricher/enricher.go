@@ -9,22 +9,21 @@ import (
 	"sync"
 
 	"github.com/IgorBayerl/nanovision/analyzer"
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/utils"
 )
 
 type Enricher struct {
-	analyzers  []analyzer.Analyzer
-	fileReader filereader.Reader
-	logger     *slog.Logger
+	analyzers []analyzer.Analyzer
+	resolver  *utils.SourceResolver
+	logger    *slog.Logger
 }
 
-func New(analyzers []analyzer.Analyzer, fileReader filereader.Reader, logger *slog.Logger) *Enricher {
+func New(analyzers []analyzer.Analyzer, resolver *utils.SourceResolver, logger *slog.Logger) *Enricher {
 	return &Enricher{
-		analyzers:  analyzers,
-		fileReader: fileReader,
-		logger:     logger,
+		analyzers: analyzers,
+		resolver:  resolver,
+		logger:    logger,
 	}
 }
 
@@ -88,8 +87,8 @@ func (e *Enricher) enrichFileNode(fileNode *model.FileNode) {
 	path := fileNode.Path
 
 	// Count the total number of lines in the source file.
-	if abs, err := utils.FindFileInSourceDirs(path, []string{fileNode.SourceDir}, e.fileReader, e.logger); err == nil {
-		if n, err := e.fileReader.CountLines(abs); err == nil {
+	if abs, err := e.sourcePath(fileNode); err == nil {
+		if n, err := e.resolver.CountLines(abs); err == nil {
 			// Set the total lines. Aggregation is handled later, so no need to update parents here.
 			fileNode.TotalLines = n
 			fileNode.Metrics.TotalLines = n
@@ -103,38 +102,57 @@ func (e *Enricher) enrichFileNode(fileNode *model.FileNode) {
 	// Find a suitable analyzer for the file.
 	analyzer := e.findAnalyzerForFile(path)
 	if analyzer == nil {
-		return // No analysis needed for this file type.
+		// Without an analyzer, the only method data available is what the
+		// coverage report itself provided.
+		applyReportedMethodCoverage(fileNode)
+		return
 	}
 
 	e.logger.Info("Analyzing file", "path", path, "analyzer", analyzer.Name())
 	sourceBytes, err := e.readSourceFile(fileNode)
 	if err != nil {
 		e.logger.Warn("Could not read source file for analysis", "file", path, "error", err)
+		applyReportedMethodCoverage(fileNode)
 		return
 	}
 
 	analysis, err := analyzer.Analyze(sourceBytes)
 	if err != nil {
 		e.logger.Warn("Static analysis failed for file", "file", path, "error", err)
+		applyReportedMethodCoverage(fileNode)
 		return
 	}
 
 	e.applyAnalysisToFileNode(fileNode, analysis)
 }
 
-// readSourceFile locates and reads the content of a source file from disk.
-// It uses the file's associated source directory to resolve its absolute path
-// via utils.FindFileInSourceDirs. The file content is returned as a byte slice,
-// which is the required input for the static code analyzers.
+// readSourceFile reads the content of a source file from disk. The file content
+// is returned as a byte slice, which is the required input for the static code
+// analyzers.
 func (e *Enricher) readSourceFile(fileNode *model.FileNode) ([]byte, error) {
-	sourceDirs := []string{fileNode.SourceDir}
-	absPath, err := utils.FindFileInSourceDirs(fileNode.Path, sourceDirs, e.fileReader, e.logger)
+	absPath, err := e.sourcePath(fileNode)
 	if err != nil {
 		return nil, err
 	}
 	return os.ReadFile(absPath)
 }
 
+// sourcePath returns the absolute path of a file's source. The tree builder
+// already resolved it for every file it created, so the resolver is only
+// asked for nodes that were made some other way, and the result is kept on
+// the node.
+func (e *Enricher) sourcePath(fileNode *model.FileNode) (string, error) {
+	if fileNode.AbsolutePath != "" {
+		return fileNode.AbsolutePath, nil
+	}
+	resolution, err := e.resolver.Resolve(fileNode.Path, []string{fileNode.SourceDir})
+	if err != nil {
+		return "", err
+	}
+	fileNode.AbsolutePath = resolution.Path
+	return resolution.Path, nil
+}
+
 // applyAnalysisToFileNode translates the generic results from an analyzer into
 // the specific data structures of the application's model.
 //
@@ -156,6 +174,18 @@ func (e *Enricher) applyAnalysisToFileNode(fileNode *model.FileNode, analysis an
 	fileNode.Methods = methodMetrics
 }
 
+// applyReportedMethodCoverage computes the coverage of the methods that were
+// provided by the coverage report rather than by an analyzer. Their boundaries
+// are kept as reported; only the coverage counters are (re)calculated.
+func applyReportedMethodCoverage(fileNode *model.FileNode) {
+	for i := range fileNode.Methods {
+		method := &fileNode.Methods[i]
+		method.LinesValid, method.LinesCovered = 0, 0
+		method.BranchesValid, method.BranchesCovered = 0, 0
+		calculateMethodCoverage(fileNode, method)
+	}
+}
+
 // calculateMethodCoverage computes the line and branch coverage for a single method
 // by examining the coverage data of the lines within its start and end boundaries.
 //
@@ -0,0 +1,257 @@
+// Package history keeps a compact snapshot of every run in a directory, so
+// the reports can show how coverage changed over time.
+//
+// Each snapshot is a small JSON file holding the totals and the metrics of
+// every directory and file, along with the tag, the time and the commit of
+// the run. Snapshots are merged by tag: saving a run replaces the earlier
+// snapshots with the same tag, so rerunning a build does not add a point to
+// the trend. Untagged runs are always kept apart.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+const (
+	filePrefix = "coverage-"
+	fileSuffix = ".json"
+)
+
+// Store reads and writes the snapshots of a history directory.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store for the given directory. The directory is
+// created when the first snapshot is saved.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// entry is a snapshot along with the file it was read from.
+type entry struct {
+	path     string
+	snapshot model.HistorySnapshot
+}
+
+// NewSnapshot records the metrics of the tree as a snapshot taken at the
+// given time.
+func NewSnapshot(tree *model.SummaryTree, tag, commit string, at time.Time) model.HistorySnapshot {
+	snapshot := model.HistorySnapshot{
+		Tag:         tag,
+		Timestamp:   at.Unix(),
+		Commit:      commit,
+		Metrics:     tree.Metrics,
+		Directories: make(map[string]model.CoverageMetrics),
+		Files:       make(map[string]model.CoverageMetrics),
+	}
+
+	var walk func(dir *model.DirNode)
+	walk = func(dir *model.DirNode) {
+		if dir == nil {
+			return
+		}
+		for _, subdir := range dir.Subdirs {
+			snapshot.Directories[subdir.Path] = subdir.Metrics
+			walk(subdir)
+		}
+		for _, file := range dir.Files {
+			snapshot.Files[file.Path] = file.Metrics
+		}
+	}
+	walk(tree.Root)
+	return snapshot
+}
+
+// Load returns the snapshots of the directory, oldest first, keeping only
+// the newest snapshot of each tag. A missing directory has no snapshots.
+// Files that cannot be read are skipped and reported in the returned error,
+// along with the snapshots that could be read.
+func (s *Store) Load() ([]model.HistorySnapshot, error) {
+	entries, err := s.entries()
+	entries = mergeByTag(entries)
+
+	snapshots := make([]model.HistorySnapshot, 0, len(entries))
+	for _, e := range entries {
+		snapshots = append(snapshots, e.snapshot)
+	}
+	return snapshots, err
+}
+
+// Save writes the snapshot and removes the earlier snapshots with the same
+// tag.
+func (s *Store) Save(snapshot model.HistorySnapshot) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create history directory '%s': %w", s.dir, err)
+	}
+
+	// Unreadable files cannot be replaced, but they do not prevent saving.
+	existing, _ := s.entries()
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history snapshot: %w", err)
+	}
+	path := s.snapshotPath(snapshot, existing)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write history snapshot '%s': %w", path, err)
+	}
+
+	if snapshot.Tag == "" {
+		return nil
+	}
+	for _, e := range existing {
+		if e.snapshot.Tag == snapshot.Tag && e.path != path {
+			if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove replaced history snapshot '%s': %w", e.path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Prune removes the snapshots older than maxAge and then the oldest
+// snapshots beyond maxCount, and returns how many it removed. A zero limit
+// is not applied. Files that cannot be read are left in place and reported
+// in the returned error, while the snapshots that could be read are pruned.
+func (s *Store) Prune(maxCount int, maxAge time.Duration, now time.Time) (int, error) {
+	entries, readErr := s.entries()
+
+	var remove []entry
+	if maxAge > 0 {
+		cutoff := now.Add(-maxAge).Unix()
+		kept := entries[:0]
+		for _, e := range entries {
+			if e.snapshot.Timestamp < cutoff {
+				remove = append(remove, e)
+			} else {
+				kept = append(kept, e)
+			}
+		}
+		entries = kept
+	}
+	if maxCount > 0 && len(entries) > maxCount {
+		remove = append(remove, entries[:len(entries)-maxCount]...)
+	}
+
+	for i, e := range remove {
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return i, errors.Join(readErr, fmt.Errorf("failed to remove history snapshot '%s': %w", e.path, err))
+		}
+	}
+	return len(remove), readErr
+}
+
+// ExcludeTag returns the snapshots whose tag differs from the given one, so
+// that a rerun is not compared with the run it replaces. An empty tag
+// excludes nothing.
+func ExcludeTag(snapshots []model.HistorySnapshot, tag string) []model.HistorySnapshot {
+	if tag == "" {
+		return snapshots
+	}
+	var kept []model.HistorySnapshot
+	for _, snapshot := range snapshots {
+		if snapshot.Tag != tag {
+			kept = append(kept, snapshot)
+		}
+	}
+	return kept
+}
+
+// entries reads every snapshot of the directory, oldest first.
+func (s *Store) entries() ([]entry, error) {
+	dirEntries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read history directory '%s': %w", s.dir, err)
+	}
+
+	var entries []entry
+	var errs []error
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasPrefix(name, filePrefix) || !strings.HasSuffix(name, fileSuffix) {
+			continue
+		}
+		path := filepath.Join(s.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read history snapshot '%s': %w", path, err))
+			continue
+		}
+		var snapshot model.HistorySnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			errs = append(errs, fmt.Errorf("failed to parse history snapshot '%s': %w", path, err))
+			continue
+		}
+		entries = append(entries, entry{path: path, snapshot: snapshot})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].snapshot.Timestamp != entries[j].snapshot.Timestamp {
+			return entries[i].snapshot.Timestamp < entries[j].snapshot.Timestamp
+		}
+		return entries[i].path < entries[j].path
+	})
+	return entries, errors.Join(errs...)
+}
+
+// mergeByTag keeps the newest of the entries sharing a tag. The entries must
+// be ordered oldest first.
+func mergeByTag(entries []entry) []entry {
+	newest := make(map[string]int)
+	for i, e := range entries {
+		if e.snapshot.Tag != "" {
+			newest[e.snapshot.Tag] = i
+		}
+	}
+
+	var merged []entry
+	for i, e := range entries {
+		if e.snapshot.Tag == "" || newest[e.snapshot.Tag] == i {
+			merged = append(merged, e)
+		}
+	}
+	return merged
+}
+
+// snapshotPath returns the path to save the snapshot at. The file is named
+// after the time and tag of the snapshot, so the directory lists in
+// chronological order. Runs within the same second, and tags that are stored
+// under the same name, such as "a/b" and "a_b", get a counter rather than
+// overwrite each other. Only a snapshot with the same tag, which the new one
+// replaces anyway, is overwritten.
+func (s *Store) snapshotPath(snapshot model.HistorySnapshot, existing []entry) string {
+	name := filePrefix + time.Unix(snapshot.Timestamp, 0).UTC().Format("20060102-150405")
+	if snapshot.Tag != "" {
+		name += "-" + utils.ReplaceInvalidPathChars(snapshot.Tag)
+	}
+
+	replaceable := make(map[string]bool)
+	for _, e := range existing {
+		if snapshot.Tag != "" && e.snapshot.Tag == snapshot.Tag {
+			replaceable[e.path] = true
+		}
+	}
+
+	path := filepath.Join(s.dir, name+fileSuffix)
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(path); os.IsNotExist(err) || replaceable[path] {
+			return path
+		}
+		// "_" sorts after the "." of the suffix, so later runs list last.
+		path = filepath.Join(s.dir, fmt.Sprintf("%s_%d%s", name, n, fileSuffix))
+	}
+}
@@ -0,0 +1,204 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/nanovision/internal/history"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseTime = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func snapshot(tag string, day int, covered int) model.HistorySnapshot {
+	return model.HistorySnapshot{
+		Tag:       tag,
+		Timestamp: baseTime.AddDate(0, 0, day).Unix(),
+		Metrics:   model.CoverageMetrics{LinesCovered: covered, LinesValid: 100},
+	}
+}
+
+func tags(snapshots []model.HistorySnapshot) []string {
+	var result []string
+	for _, s := range snapshots {
+		result = append(result, s.Tag)
+	}
+	return result
+}
+
+func TestNewSnapshot(t *testing.T) {
+	root := &model.DirNode{Path: ".", Subdirs: make(map[string]*model.DirNode), Files: make(map[string]*model.FileNode)}
+	pkg := &model.DirNode{Path: "pkg", Parent: root, Files: make(map[string]*model.FileNode)}
+	root.Subdirs["pkg"] = pkg
+	root.Files["main.go"] = &model.FileNode{Path: "main.go", Metrics: model.CoverageMetrics{LinesCovered: 1, LinesValid: 2}}
+	pkg.Metrics = model.CoverageMetrics{LinesCovered: 3, LinesValid: 4}
+	pkg.Files["a.go"] = &model.FileNode{Path: "pkg/a.go", Metrics: pkg.Metrics, Lines: map[int]model.LineMetrics{1: {Hits: 1}}}
+	tree := &model.SummaryTree{Root: root, Metrics: model.CoverageMetrics{LinesCovered: 4, LinesValid: 6}}
+
+	s := history.NewSnapshot(tree, "build-7", "abc123", baseTime)
+
+	assert.Equal(t, "build-7", s.Tag)
+	assert.Equal(t, "abc123", s.Commit)
+	assert.Equal(t, baseTime.Unix(), s.Timestamp)
+	assert.Equal(t, tree.Metrics, s.Metrics)
+	assert.Equal(t, map[string]model.CoverageMetrics{"pkg": pkg.Metrics}, s.Directories)
+	assert.Equal(t, map[string]model.CoverageMetrics{
+		"main.go":  {LinesCovered: 1, LinesValid: 2},
+		"pkg/a.go": pkg.Metrics,
+	}, s.Files)
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	testCases := []struct {
+		name         string
+		saved        []model.HistorySnapshot
+		expectedTags []string
+		expectedLine []int
+	}{
+		{
+			name:         "Snapshots are loaded oldest first",
+			saved:        []model.HistorySnapshot{snapshot("b", 2, 60), snapshot("a", 1, 50), snapshot("c", 3, 70)},
+			expectedTags: []string{"a", "b", "c"},
+			expectedLine: []int{50, 60, 70},
+		},
+		{
+			name:         "A rerun with the same tag replaces the earlier snapshot",
+			saved:        []model.HistorySnapshot{snapshot("a", 1, 50), snapshot("b", 2, 60), snapshot("a", 3, 55)},
+			expectedTags: []string{"b", "a"},
+			expectedLine: []int{60, 55},
+		},
+		{
+			name:         "Untagged runs are kept apart",
+			saved:        []model.HistorySnapshot{snapshot("", 1, 50), snapshot("", 2, 60)},
+			expectedTags: []string{"", ""},
+			expectedLine: []int{50, 60},
+		},
+		{
+			name:         "Tags with path separators are stored safely",
+			saved:        []model.HistorySnapshot{snapshot("release/1.0", 1, 50), snapshot("release/1.0", 2, 60)},
+			expectedTags: []string{"release/1.0"},
+			expectedLine: []int{60},
+		},
+		{
+			name:         "Untagged runs within the same second are kept apart",
+			saved:        []model.HistorySnapshot{snapshot("", 1, 50), snapshot("", 1, 60), snapshot("", 1, 70)},
+			expectedTags: []string{"", "", ""},
+			expectedLine: []int{50, 60, 70},
+		},
+		{
+			name:         "Tags stored under the same name are kept apart",
+			saved:        []model.HistorySnapshot{snapshot("a/b", 1, 50), snapshot("a_b", 1, 60)},
+			expectedTags: []string{"a/b", "a_b"},
+			expectedLine: []int{50, 60},
+		},
+		{
+			name:         "A rerun within the same second replaces the earlier snapshot",
+			saved:        []model.HistorySnapshot{snapshot("a", 1, 50), snapshot("a", 1, 55)},
+			expectedTags: []string{"a"},
+			expectedLine: []int{55},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "history")
+			store := history.NewStore(dir)
+			for _, s := range tc.saved {
+				require.NoError(t, store.Save(s))
+			}
+
+			loaded, err := store.Load()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTags, tags(loaded))
+			var lines []int
+			for _, s := range loaded {
+				lines = append(lines, s.Metrics.LinesCovered)
+			}
+			assert.Equal(t, tc.expectedLine, lines)
+
+			files, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, files, len(tc.expectedTags), "Replaced snapshots should be removed from the directory")
+		})
+	}
+}
+
+func TestStore_LoadMissingDirectory(t *testing.T) {
+	loaded, err := history.NewStore(filepath.Join(t.TempDir(), "missing")).Load()
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
+
+func TestStore_LoadSkipsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(dir)
+	require.NoError(t, store.Save(snapshot("a", 1, 50)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "coverage-broken.json"), []byte("{"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("unrelated"), 0o644))
+
+	loaded, err := store.Load()
+	assert.Error(t, err)
+	assert.Equal(t, []string{"a"}, tags(loaded))
+}
+
+func TestStore_Prune(t *testing.T) {
+	now := baseTime.AddDate(0, 0, 10)
+	testCases := []struct {
+		name          string
+		maxCount      int
+		maxAge        time.Duration
+		expectRemoved int
+		expectedTags  []string
+	}{
+		{name: "No limits keep everything", expectRemoved: 0, expectedTags: []string{"d1", "d3", "d6", "d9"}},
+		{name: "Count keeps the newest", maxCount: 2, expectRemoved: 2, expectedTags: []string{"d6", "d9"}},
+		{name: "Age removes old snapshots", maxAge: 5 * 24 * time.Hour, expectRemoved: 2, expectedTags: []string{"d6", "d9"}},
+		{name: "Both limits apply", maxCount: 1, maxAge: 8 * 24 * time.Hour, expectRemoved: 3, expectedTags: []string{"d9"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := history.NewStore(t.TempDir())
+			for _, day := range []int{1, 3, 6, 9} {
+				require.NoError(t, store.Save(snapshot("d"+string(rune('0'+day)), day, 50)))
+			}
+
+			removed, err := store.Prune(tc.maxCount, tc.maxAge, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectRemoved, removed)
+
+			loaded, err := store.Load()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTags, tags(loaded))
+		})
+	}
+}
+
+func TestStore_PruneSkipsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(dir)
+	for _, day := range []int{1, 3, 6} {
+		require.NoError(t, store.Save(snapshot("d"+string(rune('0'+day)), day, 50)))
+	}
+	broken := filepath.Join(dir, "coverage-broken.json")
+	require.NoError(t, os.WriteFile(broken, []byte("{"), 0o644))
+
+	removed, err := store.Prune(1, 0, baseTime.AddDate(0, 0, 10))
+	assert.Error(t, err)
+	assert.Equal(t, 2, removed)
+	assert.FileExists(t, broken)
+
+	loaded, _ := store.Load()
+	assert.Equal(t, []string{"d6"}, tags(loaded))
+}
+
+func TestExcludeTag(t *testing.T) {
+	snapshots := []model.HistorySnapshot{snapshot("a", 1, 50), snapshot("b", 2, 60), snapshot("", 3, 70)}
+
+	assert.Equal(t, []string{"b", ""}, tags(history.ExcludeTag(snapshots, "a")))
+	assert.Equal(t, []string{"a", "b", ""}, tags(history.ExcludeTag(snapshots, "")))
+}
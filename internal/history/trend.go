@@ -0,0 +1,73 @@
+package history
+
+import (
+	"math"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// TimeLayout is the layout the reports show the time of a snapshot with.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// sparkBars are the characters a sparkline is drawn with, lowest first.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Percentage extracts a coverage percentage from metrics. It returns NaN
+// when the metric was not measured.
+type Percentage func(model.CoverageMetrics) float64
+
+// LineCoverage, BranchCoverage and MethodCoverage are the percentages the
+// reports show trends for.
+var (
+	LineCoverage Percentage = func(m model.CoverageMetrics) float64 {
+		return utils.CalculatePercentage(m.LinesCovered, m.LinesValid, 2)
+	}
+	BranchCoverage Percentage = func(m model.CoverageMetrics) float64 {
+		return utils.CalculatePercentage(m.BranchesCovered, m.BranchesValid, 2)
+	}
+	MethodCoverage Percentage = func(m model.CoverageMetrics) float64 {
+		return utils.CalculatePercentage(m.MethodsCovered, m.MethodsValid, 2)
+	}
+)
+
+// Trend returns a percentage over the snapshots followed by the current run,
+// oldest first, limited to the last maxPoints values.
+func Trend(snapshots []model.HistorySnapshot, current model.CoverageMetrics, percentage Percentage, maxPoints int) []float64 {
+	values := make([]float64, 0, len(snapshots)+1)
+	for _, snapshot := range snapshots {
+		values = append(values, percentage(snapshot.Metrics))
+	}
+	values = append(values, percentage(current))
+	if maxPoints > 0 && len(values) > maxPoints {
+		values = values[len(values)-maxPoints:]
+	}
+	return values
+}
+
+// Sparkline draws values as a line of bars scaled between their minimum and
+// maximum, so small changes remain visible. Values that were not measured
+// (NaN) are drawn as spaces, and a flat trend as mid-height bars.
+func Sparkline(values []float64) string {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			low, high = math.Min(low, value), math.Max(high, value)
+		}
+	}
+
+	var sb strings.Builder
+	for _, value := range values {
+		switch {
+		case math.IsNaN(value):
+			sb.WriteRune(' ')
+		case high == low:
+			sb.WriteRune(sparkBars[len(sparkBars)/2-1])
+		default:
+			index := int(math.Round((value - low) / (high - low) * float64(len(sparkBars)-1)))
+			sb.WriteRune(sparkBars[index])
+		}
+	}
+	return sb.String()
+}
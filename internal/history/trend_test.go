@@ -0,0 +1,46 @@
+package history_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/history"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   []float64
+		expected string
+	}{
+		{name: "Scaled between minimum and maximum", values: []float64{50, 60, 70}, expected: "▁▅█"},
+		{name: "Flat trend", values: []float64{80, 80}, expected: "▄▄"},
+		{name: "Unmeasured values are blank", values: []float64{10, math.NaN(), 20}, expected: "▁ █"},
+		{name: "Nothing measured", values: []float64{math.NaN()}, expected: " "},
+		{name: "No values", values: nil, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, history.Sparkline(tc.values))
+		})
+	}
+}
+
+func TestTrend(t *testing.T) {
+	snapshots := []model.HistorySnapshot{
+		{Metrics: model.CoverageMetrics{LinesCovered: 1, LinesValid: 4}},
+		{Metrics: model.CoverageMetrics{LinesCovered: 2, LinesValid: 4}},
+		{Metrics: model.CoverageMetrics{LinesCovered: 3, LinesValid: 4}},
+	}
+	current := model.CoverageMetrics{LinesCovered: 4, LinesValid: 4}
+
+	assert.Equal(t, []float64{25, 50, 75, 100}, history.Trend(snapshots, current, history.LineCoverage, 0))
+	assert.Equal(t, []float64{75, 100}, history.Trend(snapshots, current, history.LineCoverage, 2))
+
+	branches := history.Trend(nil, current, history.BranchCoverage, 0)
+	assert.Len(t, branches, 1)
+	assert.True(t, math.IsNaN(branches[0]), "Branch coverage was not measured")
+}
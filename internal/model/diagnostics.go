@@ -0,0 +1,55 @@
+package model
+
+// DiagnosticKind classifies a file of a coverage report that did not map
+// cleanly onto exactly one source file of the project.
+type DiagnosticKind string
+
+const (
+	// DiagnosticUnresolved marks a file that was not found in the source
+	// directory. Its coverage is missing from the report.
+	DiagnosticUnresolved DiagnosticKind = "unresolved"
+	// DiagnosticAmbiguous marks a file that matched several source files
+	// equally well. Its coverage is missing from the report.
+	DiagnosticAmbiguous DiagnosticKind = "ambiguous"
+	// DiagnosticFuzzyMatch marks a file that was only found by matching the
+	// end of its path or by searching for its name. Its coverage is included,
+	// but may belong to another file with the same name.
+	DiagnosticFuzzyMatch DiagnosticKind = "fuzzy-match"
+	// DiagnosticFiltered marks a file that was excluded by the file filters.
+	DiagnosticFiltered DiagnosticKind = "filtered"
+)
+
+// DiagnosticKinds lists the kinds in the order reporters present them.
+var DiagnosticKinds = []DiagnosticKind{
+	DiagnosticUnresolved,
+	DiagnosticAmbiguous,
+	DiagnosticFuzzyMatch,
+	DiagnosticFiltered,
+}
+
+// FileDiagnostic describes a file of a coverage report that needs the user's
+// attention, together with the report that referenced it.
+type FileDiagnostic struct {
+	Kind         DiagnosticKind `json:"kind"`
+	Path         string         `json:"path"`                   // The path as written in the report.
+	Report       string         `json:"report"`                 // The report file that referenced the path.
+	ResolvedPath string         `json:"resolvedPath,omitempty"` // The source file the path was matched to, if any.
+	Candidates   []string       `json:"candidates,omitempty"`   // The source files an ambiguous path matched.
+	Detail       string         `json:"detail,omitempty"`       // Why the file was reported, e.g. the matching strategy.
+}
+
+// Title returns the heading reporters use for a group of diagnostics.
+func (k DiagnosticKind) Title() string {
+	switch k {
+	case DiagnosticUnresolved:
+		return "Unresolved Files"
+	case DiagnosticAmbiguous:
+		return "Ambiguous Files"
+	case DiagnosticFuzzyMatch:
+		return "Fuzzy-Matched Files"
+	case DiagnosticFiltered:
+		return "Filtered Files"
+	default:
+		return string(k)
+	}
+}
@@ -0,0 +1,38 @@
+package model
+
+// DiffStatus tells how a directory or file changed between two runs.
+type DiffStatus string
+
+const (
+	DiffAdded     DiffStatus = "added"
+	DiffRemoved   DiffStatus = "removed"
+	DiffChanged   DiffStatus = "changed"
+	DiffUnchanged DiffStatus = "unchanged"
+)
+
+// NodeDiff compares the metrics of the project, a directory or a file
+// between a base and a head run.
+type NodeDiff struct {
+	Path   string
+	Status DiffStatus
+	Base   CoverageMetrics // Zero when the node was added.
+	Head   CoverageMetrics // Zero when the node was removed.
+}
+
+// FileDiff compares a file between two runs, down to its lines. Lines are
+// compared by number and only where they are coverable in both runs, so edits
+// that move code can still show up as changes, while lines that only the head
+// has are never listed. Added and removed files list no lines.
+type FileDiff struct {
+	NodeDiff
+	NewlyCovered   []int // Lines covered in the head run that were coverable but not covered in the base run.
+	NewlyUncovered []int // Lines not covered in the head run that were covered in the base run.
+}
+
+// TreeDiff is the difference between the coverage of two runs, e.g. of two
+// release branches.
+type TreeDiff struct {
+	Project     NodeDiff
+	Directories []NodeDiff // Ordered by path.
+	Files       []FileDiff // Ordered by path.
+}
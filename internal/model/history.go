@@ -0,0 +1,13 @@
+package model
+
+// HistorySnapshot is a compact record of the coverage of one run. Snapshots
+// are kept in a history directory, so later runs can show how the coverage
+// of the project, its directories and its files changed over time.
+type HistorySnapshot struct {
+	Tag         string                     `json:"tag,omitempty"`    // The tag of the run, e.g. a build number. Runs with the same tag replace each other.
+	Timestamp   int64                      `json:"timestamp"`        // When the run was recorded, in Unix seconds.
+	Commit      string                     `json:"commit,omitempty"` // The commit the run was made for, if known.
+	Metrics     CoverageMetrics            `json:"metrics"`
+	Directories map[string]CoverageMetrics `json:"directories,omitempty"` // Keyed by project-relative path.
+	Files       map[string]CoverageMetrics `json:"files,omitempty"`       // Keyed by project-relative path.
+}
@@ -26,6 +26,18 @@ type LineMetrics struct {
 
 	CoveredBranches int
 	TotalBranches   int
+
+	// CoveredStatements and TotalStatements describe the statements that
+	// start or end on this line, for formats that record coverage per
+	// statement block rather than per line (e.g. Go cover profiles). A line
+	// whose statements disagree is partially covered.
+	CoveredStatements int
+	TotalStatements   int
+
+	// UnexecutedBlock marks a line with code that never ran, for formats that
+	// flag it without counting statements (e.g. gcov's unexecuted blocks). An
+	// executed line with an unexecuted block is partially covered.
+	UnexecutedBlock bool
 }
 
 // MethodMetrics holds all analysis and coverage data for a single function or method.
@@ -0,0 +1,35 @@
+package model
+
+// PatchMetrics holds the coverage of the lines a diff adds or changes.
+type PatchMetrics struct {
+	LinesChanged    int // Lines added or changed by the diff, coverable or not.
+	LinesValid      int // Changed lines that are coverable.
+	LinesCovered    int
+	BranchesValid   int // Branches on changed lines.
+	BranchesCovered int
+}
+
+// Add accumulates the metrics of another file or patch.
+func (m *PatchMetrics) Add(other PatchMetrics) {
+	m.LinesChanged += other.LinesChanged
+	m.LinesValid += other.LinesValid
+	m.LinesCovered += other.LinesCovered
+	m.BranchesValid += other.BranchesValid
+	m.BranchesCovered += other.BranchesCovered
+}
+
+// FilePatchCoverage is the patch coverage of a single changed file.
+type FilePatchCoverage struct {
+	Path           string // Project-relative path, as in the tree, or the diff's path for files the reports do not cover.
+	InReport       bool   // Whether the coverage reports include the file.
+	Metrics        PatchMetrics
+	UncoveredLines []int // Changed lines that are coverable but were not hit, in ascending order.
+}
+
+// PatchCoverage is the coverage of the code changed by a diff, so reviewers
+// can judge the tests of new code apart from the whole project.
+type PatchCoverage struct {
+	Base    string              // What the diff was taken against, e.g. "origin/main...HEAD" or a diff file.
+	Metrics PatchMetrics        // Totals over all changed files.
+	Files   []FilePatchCoverage // Changed files, ordered by path.
+}
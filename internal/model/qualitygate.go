@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GateMetric names a coverage metric a quality gate checks.
+type GateMetric string
+
+const (
+	GateLineCoverage   GateMetric = "lineCoverage"
+	GateBranchCoverage GateMetric = "branchCoverage"
+	GateMethodCoverage GateMetric = "methodCoverage"
+)
+
+// Title returns the name reporters print for the metric.
+func (m GateMetric) Title() string {
+	switch m {
+	case GateLineCoverage:
+		return "Line coverage"
+	case GateBranchCoverage:
+		return "Branch coverage"
+	case GateMethodCoverage:
+		return "Method coverage"
+	default:
+		return string(m)
+	}
+}
+
+// GateResult is the outcome of checking one coverage metric of the project,
+// a directory or a file against a minimum percentage.
+type GateResult struct {
+	Path    string // Project-relative path of the checked node, empty for the whole project.
+	Metric  GateMetric
+	Minimum float64 // The required percentage.
+	Actual  float64 // The measured percentage, NaN when there was nothing to measure.
+	Passed  bool
+}
+
+// Measured reports whether the metric had anything to measure. A gate on a
+// metric the reports do not provide (e.g. branches in a Go cover profile)
+// passes, since failing would only punish the report format.
+func (r GateResult) Measured() bool {
+	return !math.IsNaN(r.Actual)
+}
+
+// gateResultJSON is the serialized form of a GateResult. JSON has no NaN, so
+// a percentage that was not measured is left out.
+type gateResultJSON struct {
+	Path    string
+	Metric  GateMetric
+	Minimum float64
+	Actual  *float64 `json:",omitempty"`
+	Passed  bool
+}
+
+func (r GateResult) MarshalJSON() ([]byte, error) {
+	result := gateResultJSON{Path: r.Path, Metric: r.Metric, Minimum: r.Minimum, Passed: r.Passed}
+	if r.Measured() {
+		result.Actual = &r.Actual
+	}
+	return json.Marshal(result)
+}
+
+func (r *GateResult) UnmarshalJSON(data []byte) error {
+	var result gateResultJSON
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*r = GateResult{Path: result.Path, Metric: result.Metric, Minimum: result.Minimum, Actual: math.NaN(), Passed: result.Passed}
+	if result.Actual != nil {
+		r.Actual = *result.Actual
+	}
+	return nil
+}
+
+func (r GateResult) String() string {
+	var text string
+	switch {
+	case !r.Measured():
+		text = fmt.Sprintf("%s: not measured by the reports, minimum of %s ignored", r.Metric.Title(), formatGatePercentage(r.Minimum))
+	case r.Passed:
+		text = fmt.Sprintf("%s: %s meets the minimum of %s", r.Metric.Title(), formatGatePercentage(r.Actual), formatGatePercentage(r.Minimum))
+	default:
+		text = fmt.Sprintf("%s: %s is below the minimum of %s", r.Metric.Title(), formatGatePercentage(r.Actual), formatGatePercentage(r.Minimum))
+	}
+	if r.Path != "" {
+		return r.Path + ": " + text
+	}
+	return text
+}
+
+// formatGatePercentage prints a percentage with up to two decimals, e.g. "80%" or "79.95%".
+func formatGatePercentage(percentage float64) string {
+	formatted := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", percentage), "0"), ".")
+	return formatted + "%"
+}
@@ -2,13 +2,48 @@ package model
 
 // SummaryTree is the new root of the entire analyzed coverage result.
 type SummaryTree struct {
-	Root        *DirNode        // The root directory node of the project.
-	Metrics     CoverageMetrics // Aggregated metrics for the entire project.
-	Timestamp   int64           // The timestamp of the report generation.
-	SourceFiles []string        // List of original source directories provided by the user.
-	ReportFiles []string        // List of report files that were parsed.
-	ParserNames []string        // Name of the parser(s) used.
-	ReportNames []string        // Holds the list of reports, the index of an element needs to correspond to the index of LineMetrics.ReportHits
+	Root        *DirNode         // The root directory node of the project.
+	Metrics     CoverageMetrics  // Aggregated metrics for the entire project.
+	Timestamp   int64            // The timestamp of the report generation.
+	SourceFiles []string         // List of original source directories provided by the user.
+	ReportFiles []string         // List of report files that were parsed.
+	ParserNames []string         // Name of the parser(s) used.
+	ReportNames []string         // Holds the list of reports, the index of an element needs to correspond to the index of LineMetrics.ReportHits
+	ReportModes []string         // The coverage mode of each report of ReportNames, e.g. "set" for Go cover profiles, or "" when it records none.
+	Diagnostics []FileDiagnostic // Report files that were lost, ambiguous, fuzzy-matched or filtered while building the tree.
+	GateResults []GateResult     // Outcomes of the configured quality gates, for the project and per path.
+	Patch       *PatchCoverage   // Coverage of the lines changed by a diff, nil when no diff was given.
+
+	// Baseline is the tree of an earlier run, e.g. of the target branch, that
+	// reports compare against. It is not serialized, so saved trees never
+	// nest their own baselines.
+	Baseline *SummaryTree `json:"-"`
+
+	// History holds the snapshots of earlier runs, oldest first, when a
+	// history directory is configured. It never includes the current run.
+	History []HistorySnapshot `json:"-"`
+}
+
+// FilesByPath returns every file of the tree keyed by its project-relative path.
+func (t *SummaryTree) FilesByPath() map[string]*FileNode {
+	files := make(map[string]*FileNode)
+	if t == nil {
+		return files
+	}
+	var collect func(dir *DirNode)
+	collect = func(dir *DirNode) {
+		if dir == nil {
+			return
+		}
+		for _, file := range dir.Files {
+			files[file.Path] = file
+		}
+		for _, subdir := range dir.Subdirs {
+			collect(subdir)
+		}
+	}
+	collect(t.Root)
+	return files
 }
 
 // DirNode represents a directory in the file system tree.
@@ -31,4 +66,9 @@ type FileNode struct {
 	Parent     *DirNode            `json:"-"`
 	TotalLines int                 `json:"totalLines"`
 	SourceDir  string              `json:"sourceDir"`
+
+	// AbsolutePath is where the source file was found when the tree was built.
+	// It is resolved once, so later stages read the file without looking it up
+	// again, and it is not serialized, as it only holds on this machine.
+	AbsolutePath string `json:"-"`
 }
@@ -9,6 +9,9 @@ import (
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_cobertura"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_gocover"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_lcov"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_opencover"
+	"github.com/IgorBayerl/nanovision/internal/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,16 +22,24 @@ func Test_ParserFactory_FindParserForFile(t *testing.T) {
 	coberturaFile := filepath.Join(tmpDir, "cobertura.xml")
 	_ = os.WriteFile(coberturaFile, []byte(`<?xml version="1.0" ?><coverage/>`), 0644)
 
+	opencoverFile := filepath.Join(tmpDir, "coverage.opencover.xml")
+	_ = os.WriteFile(opencoverFile, []byte(`<?xml version="1.0" ?><CoverageSession/>`), 0644)
+
 	gocoverFile := filepath.Join(tmpDir, "gocover.out")
 	_ = os.WriteFile(gocoverFile, []byte(`mode: set`), 0644)
 
+	lcovFile := filepath.Join(tmpDir, "lcov.info")
+	_ = os.WriteFile(lcovFile, []byte("TN:\nSF:main.c\nend_of_record\n"), 0644)
+
 	unknownFile := filepath.Join(tmpDir, "unknown.txt")
 	_ = os.WriteFile(unknownFile, []byte(`some data`), 0644)
 
-	fileReader := filereader.NewDefaultReader()
+	fileReader := utils.NewSourceResolver(filereader.NewDefaultReader(), nil)
 	factory := parsers.NewParserFactory(
 		parser_cobertura.NewCoberturaParser(fileReader),
 		parser_gocover.NewGoCoverParser(fileReader),
+		parser_opencover.NewOpenCoverParser(fileReader),
+		parser_lcov.NewLcovParser(fileReader),
 	)
 
 	testCases := []struct {
@@ -43,12 +54,24 @@ func Test_ParserFactory_FindParserForFile(t *testing.T) {
 			expectedType: "Cobertura",
 			expectError:  false,
 		},
+		{
+			name:         "Should select OpenCoverParser for OpenCover XML",
+			filePath:     opencoverFile,
+			expectedType: "OpenCover",
+			expectError:  false,
+		},
 		{
 			name:         "Should select GoCoverParser for Go cover profile",
 			filePath:     gocoverFile,
 			expectedType: "GoCover",
 			expectError:  false,
 		},
+		{
+			name:         "Should select LcovParser for LCOV tracefile",
+			filePath:     lcovFile,
+			expectedType: "LCOV",
+			expectError:  false,
+		},
 		{
 			name:        "Should return error for unknown file type",
 			filePath:    unknownFile,
@@ -0,0 +1,48 @@
+package parsers
+
+import (
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+)
+
+// InferMethodEndLines completes the boundaries of report-provided methods for
+// formats that only record the line a method starts on (JaCoCo, LCOV, ...).
+//
+// Methods are sorted by start line, and every method without an end line is
+// assumed to end at the last coverable line before the next method that starts
+// on a later line, or at the last coverable line of the file for the final
+// method. Methods that share a start line (lambdas, synthetic methods,
+// overloads on one line) are all bounded by the method after them.
+func InferMethodEndLines(methods []model.MethodMetrics, lines map[int]model.LineMetrics) {
+	sort.SliceStable(methods, func(i, j int) bool {
+		return methods[i].StartLine < methods[j].StartLine
+	})
+
+	lastLine := 0
+	for lineNumber := range lines {
+		lastLine = max(lastLine, lineNumber)
+	}
+
+	for i := range methods {
+		if methods[i].EndLine >= methods[i].StartLine {
+			continue // The report already provided the end line.
+		}
+
+		upperBound := lastLine
+		for next := i + 1; next < len(methods); next++ {
+			if methods[next].StartLine > methods[i].StartLine {
+				upperBound = methods[next].StartLine - 1
+				break
+			}
+		}
+
+		methods[i].EndLine = methods[i].StartLine
+		for l := upperBound; l > methods[i].StartLine; l-- {
+			if _, ok := lines[l]; ok {
+				methods[i].EndLine = l
+				break
+			}
+		}
+	}
+}
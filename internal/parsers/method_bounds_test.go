@@ -0,0 +1,66 @@
+package parsers_test
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferMethodEndLines(t *testing.T) {
+	lines := map[int]model.LineMetrics{
+		3: {Hits: 1}, 4: {Hits: 1}, 5: {Hits: 0},
+		8: {Hits: 1}, 9: {Hits: 1},
+		12: {Hits: 0}, 13: {Hits: 0},
+	}
+
+	testCases := []struct {
+		name     string
+		methods  []model.MethodMetrics
+		expected []model.MethodMetrics
+	}{
+		{
+			name: "ends before the next method",
+			methods: []model.MethodMetrics{
+				{Name: "second", StartLine: 8},
+				{Name: "first", StartLine: 3},
+			},
+			expected: []model.MethodMetrics{
+				{Name: "first", StartLine: 3, EndLine: 5},
+				{Name: "second", StartLine: 8, EndLine: 13},
+			},
+		},
+		{
+			name: "methods on the same start line end before the next method",
+			methods: []model.MethodMetrics{
+				{Name: "outer", StartLine: 3},
+				{Name: "lambda", StartLine: 3},
+				{Name: "next", StartLine: 8},
+			},
+			expected: []model.MethodMetrics{
+				{Name: "outer", StartLine: 3, EndLine: 5},
+				{Name: "lambda", StartLine: 3, EndLine: 5},
+				{Name: "next", StartLine: 8, EndLine: 13},
+			},
+		},
+		{
+			name: "keeps end lines from the report",
+			methods: []model.MethodMetrics{
+				{Name: "first", StartLine: 3, EndLine: 4},
+				{Name: "second", StartLine: 12},
+			},
+			expected: []model.MethodMetrics{
+				{Name: "first", StartLine: 3, EndLine: 4},
+				{Name: "second", StartLine: 12, EndLine: 13},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsers.InferMethodEndLines(tc.methods, lines)
+			assert.Equal(t, tc.expected, tc.methods)
+		})
+	}
+}
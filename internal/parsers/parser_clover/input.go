@@ -0,0 +1,43 @@
+package parser_clover
+
+import "encoding/xml"
+
+// <coverage>
+type CoverageXML struct {
+	XMLName   xml.Name   `xml:"coverage"`
+	Generated string     `xml:"generated,attr"`
+	Project   ProjectXML `xml:"project"`
+}
+
+// <project>
+type ProjectXML struct {
+	Name      string       `xml:"name,attr"`
+	Timestamp string       `xml:"timestamp,attr"`
+	Packages  []PackageXML `xml:"package"`
+	Files     []FileXML    `xml:"file"` // Files outside of any package.
+}
+
+// <package>
+type PackageXML struct {
+	Name  string    `xml:"name,attr"`
+	Files []FileXML `xml:"file"`
+}
+
+// <file>
+type FileXML struct {
+	Name  string    `xml:"name,attr"`
+	Path  string    `xml:"path,attr"`
+	Lines []LineXML `xml:"line"`
+}
+
+// <line>
+type LineXML struct {
+	Num        string `xml:"num,attr"`
+	Type       string `xml:"type,attr"` // "stmt", "cond" or "method"
+	Count      string `xml:"count,attr"`
+	TrueCount  string `xml:"truecount,attr"`
+	FalseCount string `xml:"falsecount,attr"`
+	Name       string `xml:"name,attr"`
+	Signature  string `xml:"signature,attr"`
+	Complexity string `xml:"complexity,attr"`
+}
@@ -0,0 +1,149 @@
+package parser_clover
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// CloverParser implements the parsers.IParser interface for Clover XML reports,
+// as written by PHPUnit, OpenClover, Kover and several JavaScript tools.
+type CloverParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewCloverParser creates a new parser instance.
+func NewCloverParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &CloverParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *CloverParser) Name() string {
+	return "Clover"
+}
+
+// SupportsFile performs a fast check to see if this parser can handle the given file.
+// Clover and Cobertura both use a "<coverage>" root, so the file must also have
+// a ".xml" extension and the root's first child element must be "<project>".
+func (p *CloverParser) SupportsFile(filePath string) bool {
+	if !strings.HasSuffix(strings.ToLower(filePath), ".xml") {
+		return false
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	decoder := xml.NewDecoder(f)
+	foundRoot := false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false // Malformed XML or end of file before any child.
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !foundRoot {
+				if t.Name.Local != "coverage" {
+					return false
+				}
+				foundRoot = true
+				continue
+			}
+			return t.Name.Local == "project"
+		case xml.EndElement:
+			return false // The root closed without children.
+		}
+	}
+}
+
+// Parse unmarshals the Clover XML report and delegates the conversion to a
+// flat list of FileCoverage objects to the processingOrchestrator.
+func (p *CloverParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	rawReport, err := p.loadAndUnmarshalCloverXML(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/unmarshal Clover XML from %s: %w", filePath, err)
+	}
+
+	files := append([]FileXML{}, rawReport.Project.Files...)
+	for _, pkg := range rawReport.Project.Packages {
+		files = append(files, pkg.Files...)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processFiles(files)
+
+	rawTimestamp := rawReport.Project.Timestamp
+	if rawTimestamp == "" {
+		rawTimestamp = rawReport.Generated
+	}
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		Timestamp:             p.getReportTimestamp(rawTimestamp, logger),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
+
+// ------ Helper Functions ------
+
+// getReportTimestamp parses the Clover timestamp. PHPUnit writes seconds while
+// OpenClover writes milliseconds, so both are accepted.
+func (p *CloverParser) getReportTimestamp(rawTimestamp string, logger *slog.Logger) *time.Time {
+	if rawTimestamp == "" {
+		return nil
+	}
+	parsedTs, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		logger.Warn("Failed to parse Clover timestamp", "timestamp", rawTimestamp, "error", err)
+		return nil
+	}
+
+	if !utils.IsValidUnixSeconds(parsedTs) && utils.IsValidUnixSeconds(parsedTs/1000) {
+		parsedTs /= 1000
+	}
+
+	if utils.IsValidUnixSeconds(parsedTs) {
+		t := time.Unix(parsedTs, 0)
+		return &t
+	}
+
+	logger.Warn("Clover timestamp is outside the valid range", "timestamp", rawTimestamp)
+	return nil
+}
+
+// loadAndUnmarshalCloverXML reads and unmarshals the Clover XML file.
+func (p *CloverParser) loadAndUnmarshalCloverXML(path string) (*CoverageXML, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	bytes, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var rawReport CoverageXML
+	if err := xml.Unmarshal(bytes, &rawReport); err != nil {
+		return nil, fmt.Errorf("unmarshal xml: %w", err)
+	}
+	return &rawReport, nil
+}
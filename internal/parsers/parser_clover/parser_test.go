@@ -0,0 +1,186 @@
+package parser_clover_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_clover"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_cobertura"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloverParser_Parse(t *testing.T) {
+	const reportFileName = "clover.xml"
+	const sourceDir = "/app"
+	const sourceFilePath = "/app/src/Calculator.php"
+
+	testCases := []struct {
+		name          string
+		reportContent string
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name: "Golden Path - PHPUnit report with statements, conditions and methods",
+			reportContent: `<?xml version="1.0" encoding="UTF-8"?>
+<coverage generated="1672531200">
+  <project timestamp="1672531200">
+    <package name="App">
+      <file name="/app/src/Calculator.php">
+        <class name="App\Calculator" namespace="App">
+          <metrics complexity="3" methods="2" coveredmethods="1" statements="5" coveredstatements="4" elements="7" coveredelements="5"/>
+        </class>
+        <line num="5" type="method" name="add" visibility="public" complexity="1" crap="1" count="2"/>
+        <line num="7" type="stmt" count="2"/>
+        <line num="10" type="method" name="sign" visibility="public" complexity="2" crap="2.15" count="3"/>
+        <line num="12" type="cond" count="3" truecount="1" falsecount="2"/>
+        <line num="13" type="stmt" count="1"/>
+        <line num="15" type="cond" truecount="2" falsecount="0"/>
+        <line num="16" type="stmt" count="0"/>
+        <metrics loc="18" ncloc="18" classes="1" methods="2" coveredmethods="1" statements="5" coveredstatements="4" elements="7" coveredelements="5"/>
+      </file>
+    </package>
+    <metrics files="1" loc="18" ncloc="18" classes="1" methods="2" coveredmethods="1" statements="5" coveredstatements="4" elements="7" coveredelements="5"/>
+  </project>
+</coverage>`,
+			sourceFiles: map[string]string{
+				sourceFilePath: `<?php // Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "Clover", result.ParserName)
+				require.NotNil(t, result.Timestamp)
+				assert.Equal(t, int64(1672531200), result.Timestamp.Unix())
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, sourceFilePath, fileCov.Path)
+				require.Len(t, fileCov.Lines, 5, "Method lines are not coverable lines")
+
+				assert.Equal(t, 2, fileCov.Lines[7].Hits)
+				assert.Equal(t, 0, fileCov.Lines[16].Hits)
+
+				assert.Equal(t, 3, fileCov.Lines[12].Hits)
+				assert.Equal(t, 2, fileCov.Lines[12].TotalBranches)
+				assert.Equal(t, 2, fileCov.Lines[12].CoveredBranches)
+				assert.Equal(t, 2, fileCov.Lines[15].Hits, "Without count, the condition evaluations are the hits")
+				assert.Equal(t, 2, fileCov.Lines[15].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[15].CoveredBranches)
+
+				require.Len(t, fileCov.Methods, 2)
+				assert.Equal(t, "add", fileCov.Methods[0].Name)
+				assert.Equal(t, 5, fileCov.Methods[0].StartLine)
+				assert.Equal(t, 7, fileCov.Methods[0].EndLine)
+				assert.Equal(t, "sign", fileCov.Methods[1].Name)
+				assert.Equal(t, 16, fileCov.Methods[1].EndLine)
+				require.NotNil(t, fileCov.Methods[1].CyclomaticComplexity)
+				assert.Equal(t, 2, *fileCov.Methods[1].CyclomaticComplexity)
+			},
+		},
+		{
+			name: "OpenClover report with paths and files outside packages",
+			reportContent: `<?xml version="1.0" encoding="UTF-8"?>
+<coverage generated="1672531200000" clover="4.4.1">
+  <project timestamp="1672531200000" name="demo">
+    <file name="Main.java" path="src/main/java/Main.java">
+      <line num="3" count="1" type="stmt"/>
+      <line num="4" count="1" type="cond"/>
+    </file>
+  </project>
+</coverage>`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result.Timestamp)
+				assert.Equal(t, int64(1672531200), result.Timestamp.Unix(), "Millisecond timestamps should be converted")
+
+				require.Len(t, result.FileCoverage, 1)
+				assert.Equal(t, "src/main/java/Main.java", result.FileCoverage[0].Path)
+				assert.Equal(t, 1, result.FileCoverage[0].Lines[3].Hits)
+				assert.Equal(t, 1, result.FileCoverage[0].Lines[4].Hits)
+				assert.Zero(t, result.FileCoverage[0].Lines[4].TotalBranches, "A condition without counts has no known branches")
+
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, "src/main/java/Main.java", result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name: "Report is logically empty (no files)",
+			reportContent: `<?xml version="1.0" encoding="UTF-8"?>
+<coverage generated="1672531200"><project timestamp="1672531200"/></coverage>`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.FileCoverage)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, reportFileName)
+			err := os.WriteFile(reportPath, []byte(tc.reportContent), 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_clover.NewCloverParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
+
+func TestCloverParser_SupportsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	mockFS := testutil.NewMockFilesystem("unix")
+	clover := parser_clover.NewCloverParser(utils.NewSourceResolver(mockFS, nil))
+	cobertura := parser_cobertura.NewCoberturaParser(utils.NewSourceResolver(mockFS, nil))
+
+	testCases := []struct {
+		name            string
+		content         string
+		expectClover    bool
+		expectCobertura bool
+	}{
+		{"Clover report", `<?xml version="1.0"?><coverage generated="1"><project timestamp="1"/></coverage>`, true, false},
+		{"Cobertura report with sources", `<?xml version="1.0"?><coverage><sources><source>/app</source></sources><packages/></coverage>`, false, true},
+		{"Cobertura report with packages", `<?xml version="1.0"?><coverage line-rate="1"><packages/></coverage>`, false, true},
+		{"Empty coverage root", `<?xml version="1.0"?><coverage/>`, false, true},
+		{"Unrelated XML", `<?xml version="1.0"?><report><project/></report>`, false, false},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reportPath := filepath.Join(tmpDir, fmt.Sprintf("report%d.xml", i))
+			require.NoError(t, os.WriteFile(reportPath, []byte(tc.content), 0644))
+
+			assert.Equal(t, tc.expectClover, clover.SupportsFile(reportPath))
+			assert.Equal(t, tc.expectCobertura, cobertura.SupportsFile(reportPath))
+		})
+	}
+}
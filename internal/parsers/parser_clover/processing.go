@@ -0,0 +1,150 @@
+package parser_clover
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// processingOrchestrator is responsible for converting the raw Clover XML data
+// into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// fileData collects the lines and methods of a file, which may be listed in
+// several <file> elements (e.g. once per package it contributes classes to).
+type fileData struct {
+	lines   map[int]model.LineMetrics
+	methods []model.MethodMetrics
+}
+
+// processFiles is the main entry point for the orchestrator. Files are returned
+// sorted by path.
+func (o *processingOrchestrator) processFiles(files []FileXML) ([]parsers.FileCoverage, []string) {
+	dataByPath := make(map[string]*fileData)
+	for _, fileXML := range files {
+		// OpenClover and Kover put the full path in "path" and the base name in
+		// "name"; PHPUnit only writes "name", holding the full path.
+		filePath := fileXML.Path
+		if filePath == "" {
+			filePath = fileXML.Name
+		}
+		filePath = filepath.ToSlash(filePath)
+		if filePath == "" {
+			continue
+		}
+
+		data, ok := dataByPath[filePath]
+		if !ok {
+			data = &fileData{lines: make(map[int]model.LineMetrics)}
+			dataByPath[filePath] = data
+		}
+		o.processLines(data, fileXML.Lines)
+	}
+
+	filePaths := make([]string, 0, len(dataByPath))
+	for filePath := range dataByPath {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+	for _, reportPath := range filePaths {
+		filePath := parsers.MapReportPath(o.config, reportPath)
+		if _, err := o.resolver.Resolve(filePath, []string{sourceDir}); err != nil {
+			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
+			unresolvedFiles = append(unresolvedFiles, filePath)
+		}
+
+		data := dataByPath[reportPath]
+		parsers.InferMethodEndLines(data.methods, data.lines)
+		finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
+			Path:    filePath,
+			Lines:   data.lines,
+			Methods: data.methods,
+		})
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
+
+// processLines converts the <line> elements of a file. Statement lines carry a
+// plain hit count. Conditional lines have two branches (true and false), each
+// covered when its count is greater than zero. Method lines only mark where a
+// method starts; they are declarations, not coverable lines.
+func (o *processingOrchestrator) processLines(data *fileData, linesXML []LineXML) {
+	for _, lineXML := range linesXML {
+		lineNumber := utils.ParseInt(lineXML.Num, 0)
+		if lineNumber <= 0 {
+			continue
+		}
+
+		if lineXML.Type == "method" {
+			if method := o.processMethod(lineXML, lineNumber); method.Name != "" {
+				data.methods = append(data.methods, method)
+			}
+			continue
+		}
+
+		metric := data.lines[lineNumber]
+		if lineXML.Type == "cond" {
+			trueCount := utils.ParseInt(lineXML.TrueCount, 0)
+			falseCount := utils.ParseInt(lineXML.FalseCount, 0)
+			// Some tools omit "count" on conditional lines; every evaluation
+			// of the condition is then an execution of the line.
+			metric.Hits += utils.ParseInt(lineXML.Count, trueCount+falseCount)
+			// Without either count, nothing is known about the branches.
+			if lineXML.TrueCount != "" || lineXML.FalseCount != "" {
+				metric.TotalBranches += 2
+				if trueCount > 0 {
+					metric.CoveredBranches++
+				}
+				if falseCount > 0 {
+					metric.CoveredBranches++
+				}
+			}
+		} else {
+			metric.Hits += utils.ParseInt(lineXML.Count, 0)
+		}
+		data.lines[lineNumber] = metric
+	}
+}
+
+// processMethod converts a method line into a method whose end line is
+// inferred later, once all lines of the file are known.
+func (o *processingOrchestrator) processMethod(lineXML LineXML, lineNumber int) model.MethodMetrics {
+	name := lineXML.Signature
+	if name == "" {
+		name = lineXML.Name
+	}
+	method := model.MethodMetrics{
+		Name:      name,
+		StartLine: lineNumber,
+	}
+	if lineXML.Complexity != "" {
+		complexity := utils.ParseInt(lineXML.Complexity, 0)
+		method.CyclomaticComplexity = &complexity
+	}
+	return method
+}
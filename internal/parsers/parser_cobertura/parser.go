@@ -10,19 +10,18 @@ import (
 	"strings"
 	"time"
 
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/utils"
 )
 
 // CoberturaParser implements the parsers.IParser interface for Cobertura XML reports.
 type CoberturaParser struct {
-	fileReader filereader.Reader
+	resolver *utils.SourceResolver
 }
 
-func NewCoberturaParser(fileReader filereader.Reader) parsers.IParser {
+func NewCoberturaParser(resolver *utils.SourceResolver) parsers.IParser {
 	return &CoberturaParser{
-		fileReader: fileReader,
+		resolver: resolver,
 	}
 }
 
@@ -32,6 +31,8 @@ func (p *CoberturaParser) Name() string {
 
 // SupportsFile performs a fast check to see if this parser can handle the given file.
 // It verifies the file has a ".xml" extension and that its root element is "<coverage>".
+// Clover reports share the same root element, so a "<coverage>" whose first child
+// is "<project>" is rejected.
 func (p *CoberturaParser) SupportsFile(filePath string) bool {
 	if !strings.HasSuffix(strings.ToLower(filePath), ".xml") {
 		return false
@@ -43,19 +44,30 @@ func (p *CoberturaParser) SupportsFile(filePath string) bool {
 	}
 	defer f.Close()
 
-	// We only need to check the very first token to identify the root element.
+	// We only need to check the root element and its first child.
 	decoder := xml.NewDecoder(f)
+	foundRoot := false
 	for {
 		token, err := decoder.Token()
 		if err == io.EOF {
-			return false // Reached end of file without finding any elements.
+			return foundRoot // An empty <coverage/> root is still Cobertura.
 		}
 		if err != nil {
 			return false // Malformed XML.
 		}
 
-		if se, ok := token.(xml.StartElement); ok {
-			return se.Name.Local == "coverage"
+		switch t := token.(type) {
+		case xml.StartElement:
+			if !foundRoot {
+				if t.Name.Local != "coverage" {
+					return false
+				}
+				foundRoot = true
+				continue
+			}
+			return t.Name.Local != "project"
+		case xml.EndElement:
+			return foundRoot // The root closed without children.
 		}
 	}
 }
@@ -70,10 +82,10 @@ func (p *CoberturaParser) Parse(filePath string, config parsers.ParserConfig) (*
 		return nil, fmt.Errorf("failed to load/unmarshal Cobertura XML from %s: %w", filePath, err)
 	}
 
-	orchestrator := newProcessingOrchestrator(p.fileReader, config, logger)
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
 
 	// The orchestrator now directly returns the flat list of file coverage data and any unresolved files.
-	fileCoverage, unresolvedFiles := orchestrator.processPackages(rawReport.Packages.Package)
+	fileCoverage, unresolvedFiles := orchestrator.processPackages(rawReport.Packages.Package, rawReport.Sources.Source)
 
 	timestamp := p.getReportTimestamp(rawReport.Timestamp, logger)
 
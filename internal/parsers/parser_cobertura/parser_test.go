@@ -8,6 +8,7 @@ import (
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_cobertura"
 	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -111,6 +112,152 @@ func TestCoberturaParser_Parse(t *testing.T) {
 				assert.Equal(t, unresolvedPath, result.UnresolvedSourceFiles[0])
 			},
 		},
+		{
+			name: "coverlet - Methods are reported and their lines are not counted twice",
+			reportContent: `<?xml version="1.0" encoding="utf-8"?>
+<coverage line-rate="0.75" branch-rate="0.5" version="1.9" timestamp="1672531200">
+  <packages>
+    <package name="MyProject" line-rate="0.75" branch-rate="0.5" complexity="3">
+      <classes>
+        <class name="MyProject.Calculator" filename="MyProject/Calculator.cs" line-rate="0.75" branch-rate="0.5" complexity="3">
+          <methods>
+            <method name="Sign" signature="(System.Int32)" line-rate="0.66" branch-rate="0.5" complexity="2">
+              <lines>
+                <line number="10" hits="2" branch="true" condition-coverage="50% (1/2)" />
+                <line number="11" hits="0" branch="false" />
+                <line number="13" hits="2" branch="false" />
+              </lines>
+            </method>
+            <method name="Add" signature="(System.Int32,System.Int32)" line-rate="1" branch-rate="1" complexity="1">
+              <lines>
+                <line number="5" hits="1" branch="false" />
+              </lines>
+            </method>
+            <method name="Empty" signature="()" line-rate="1" branch-rate="1" complexity="1">
+              <lines />
+            </method>
+          </methods>
+          <lines>
+            <line number="5" hits="1" branch="false" />
+            <line number="10" hits="2" branch="true" condition-coverage="50% (1/2)" />
+            <line number="11" hits="0" branch="false" />
+            <line number="13" hits="2" branch="false" />
+          </lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`,
+			sourceFiles: map[string]string{
+				resolvedSourcePath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+
+				require.Len(t, fileCov.Lines, 4)
+				assert.Equal(t, 2, fileCov.Lines[10].Hits, "Method lines repeated in the class should not be summed")
+				assert.Equal(t, 2, fileCov.Lines[10].TotalBranches)
+
+				require.Len(t, fileCov.Methods, 2, "Methods without lines should be skipped")
+				assert.Equal(t, "Add(System.Int32,System.Int32)", fileCov.Methods[0].Name)
+				assert.Equal(t, 5, fileCov.Methods[0].StartLine)
+				assert.Equal(t, 5, fileCov.Methods[0].EndLine)
+
+				assert.Equal(t, "Sign(System.Int32)", fileCov.Methods[1].Name)
+				assert.Equal(t, 10, fileCov.Methods[1].StartLine)
+				assert.Equal(t, 13, fileCov.Methods[1].EndLine)
+				require.NotNil(t, fileCov.Methods[1].CyclomaticComplexity)
+				assert.Equal(t, 2, *fileCov.Methods[1].CyclomaticComplexity)
+			},
+		},
+		{
+			name: "Sources from another machine are rebased onto the source directory",
+			reportContent: `<?xml version="1.0"?>
+<coverage>
+  <sources>
+    <source>C:\agent\_work\1\s\MyProject</source>
+    <source>/build/src</source>
+  </sources>
+  <packages>
+    <package name="MyProject.Core">
+      <classes>
+        <class name="MyProject.Core.Calculator" filename="Calculator.cs">
+          <lines><line number="5" hits="1" /></lines>
+        </class>
+        <class name="math.c" filename="lib/math.c">
+          <lines><line number="3" hits="0" /></lines>
+        </class>
+        <class name="missing.c" filename="lib/missing.c">
+          <lines><line number="1" hits="0" /></lines>
+        </class>
+      </classes>
+    </package>
+  </packages>
+</coverage>`,
+			sourceFiles: map[string]string{
+				resolvedSourcePath:    `// Dummy content`,
+				"/app/src/lib/math.c": `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 3)
+
+				paths := make(map[string]bool)
+				for _, fileCov := range result.FileCoverage {
+					paths[fileCov.Path] = true
+				}
+				assert.True(t, paths[resolvedSourcePath], "The Windows source should be rebased onto /app/src/MyProject")
+				assert.True(t, paths["/app/src/lib/math.c"], "The container source should be rebased onto /app/src")
+				assert.True(t, paths["lib/missing.c"], "Unresolved files keep the path of the report")
+
+				assert.Equal(t, []string{"lib/missing.c"}, result.UnresolvedSourceFiles)
+			},
+		},
+		{
+			name: "coverage.py XML - Millisecond timestamp and missing branches",
+			reportContent: `<?xml version="1.0" ?>
+<coverage version="7.4.0" timestamp="1704110400123" lines-valid="3" lines-covered="2" line-rate="0.6667" branches-covered="1" branches-valid="2" branch-rate="0.5" complexity="0">
+	<sources>
+		<source>/app/src</source>
+	</sources>
+	<packages>
+		<package name="MyProject" line-rate="0.6667" branch-rate="0.5" complexity="0">
+			<classes>
+				<class name="Calculator.cs" filename="MyProject/Calculator.cs" complexity="0" line-rate="0.6667" branch-rate="0.5">
+					<methods/>
+					<lines>
+						<line number="1" hits="1"/>
+						<line number="2" hits="1" branch="true" condition-coverage="50% (1/2)" missing-branches="4"/>
+						<line number="4" hits="0"/>
+					</lines>
+				</class>
+			</classes>
+		</package>
+	</packages>
+</coverage>`,
+			sourceFiles: map[string]string{
+				resolvedSourcePath: `# Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				require.NotNil(t, result.Timestamp)
+				assert.Equal(t, int64(1704110400), result.Timestamp.Unix())
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				require.Len(t, fileCov.Lines, 3)
+				assert.Equal(t, 0, fileCov.Lines[4].Hits)
+				assert.Equal(t, 2, fileCov.Lines[2].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[2].CoveredBranches)
+			},
+		},
 		{
 			name: "Report is logically empty (no packages)",
 			reportContent: `<?xml version="1.0"?>
@@ -142,7 +289,7 @@ func TestCoberturaParser_Parse(t *testing.T) {
 			}
 
 			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
-			parser := parser_cobertura.NewCoberturaParser(mockFS)
+			parser := parser_cobertura.NewCoberturaParser(utils.NewSourceResolver(mockFS, nil))
 
 			// Act
 			result, err := parser.Parse(reportPath, mockConfig)
@@ -2,12 +2,13 @@ package parser_cobertura
 
 import (
 	"log/slog"
+	"math"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/utils"
@@ -20,26 +21,34 @@ var (
 // processingOrchestrator is responsible for converting the raw XML data into
 // a flat list of per-file coverage metrics.
 type processingOrchestrator struct {
-	fileReader filereader.Reader
-	config     parsers.ParserConfig
-	logger     *slog.Logger
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
 }
 
 func newProcessingOrchestrator(
-	fileReader filereader.Reader,
+	resolver *utils.SourceResolver,
 	config parsers.ParserConfig,
 	logger *slog.Logger,
 ) *processingOrchestrator {
 	return &processingOrchestrator{
-		fileReader: fileReader,
-		config:     config,
-		logger:     logger,
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
 	}
 }
 
-// processPackages is the main entry point for the orchestrator.
-func (o *processingOrchestrator) processPackages(packages []PackageXML) ([]parsers.FileCoverage, []string) {
-	fileData := make(map[string]map[int]model.LineMetrics)
+// fileData accumulates the lines and methods of a single file, which can be
+// described by several <class> elements.
+type fileData struct {
+	lines   map[int]model.LineMetrics
+	methods []model.MethodMetrics
+}
+
+// processPackages is the main entry point for the orchestrator. The <source>
+// entries of the report, if any, are tried before the source directory.
+func (o *processingOrchestrator) processPackages(packages []PackageXML, sources []string) ([]parsers.FileCoverage, []string) {
+	dataByFile := make(map[string]*fileData)
 	var unresolvedFiles []string
 
 	for _, pkgXML := range packages {
@@ -48,14 +57,13 @@ func (o *processingOrchestrator) processPackages(packages []PackageXML) ([]parse
 			if filePath == "" {
 				continue
 			}
-			if _, ok := fileData[filePath]; !ok {
-				fileData[filePath] = make(map[int]model.LineMetrics)
+			data, ok := dataByFile[filePath]
+			if !ok {
+				data = &fileData{lines: make(map[int]model.LineMetrics)}
+				dataByFile[filePath] = data
 			}
-			allLinesInClass := classXML.Lines.Line
-			for _, methodXML := range classXML.Methods.Method {
-				allLinesInClass = append(allLinesInClass, methodXML.Lines.Line...)
-			}
-			o.mergeLinesIntoFile(fileData[filePath], allLinesInClass)
+			o.mergeLinesIntoFile(data.lines, o.collectClassLines(classXML))
+			data.methods = append(data.methods, o.processMethods(classXML.Methods.Method)...)
 		}
 	}
 
@@ -64,22 +72,180 @@ func (o *processingOrchestrator) processPackages(packages []PackageXML) ([]parse
 	if len(o.config.SourceDirectories()) > 0 {
 		sourceDir = o.config.SourceDirectories()[0]
 	}
+	sourceRoots := o.resolveSourceRoots(sources, sourceDir)
 
-	for path, lines := range fileData {
-		if _, err := utils.FindFileInSourceDirs(path, []string{sourceDir}, o.fileReader, o.logger); err != nil {
+	for reportPath, data := range dataByFile {
+		// A file path rewritten by the path mappings is relative to the
+		// source directory rather than to the report sources.
+		path := parsers.MapReportPath(o.config, reportPath)
+		roots := sourceRoots
+		if path != reportPath {
+			roots = nil
+		}
+		if resolvedPath, ok := o.findInSourceRoots(path, roots); ok {
+			// Reporting the resolved path spares later stages from searching
+			// the source directory for a file the report already located.
+			path = resolvedPath
+		} else if _, err := o.resolver.Resolve(path, []string{sourceDir}); err != nil {
 			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", path, "error", err)
 			unresolvedFiles = append(unresolvedFiles, path)
 		}
 
+		sort.Slice(data.methods, func(i, j int) bool {
+			if data.methods[i].StartLine != data.methods[j].StartLine {
+				return data.methods[i].StartLine < data.methods[j].StartLine
+			}
+			return data.methods[i].Name < data.methods[j].Name
+		})
 		finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
-			Path:  path,
-			Lines: lines,
+			Path:    path,
+			Lines:   data.lines,
+			Methods: data.methods,
 		})
 	}
 
 	return finalFileCoverage, unresolvedFiles
 }
 
+// resolveSourceRoots turns the <source> entries of the report into existing
+// local directories. Reports are often written on a CI machine or in a
+// container (e.g. under /build/src), so a source that does not exist locally
+// is rebased onto the source directory: the longest trailing part of it that
+// exists below the source directory is used, or the source directory itself.
+// Sources are rewritten with the path mappings first.
+func (o *processingOrchestrator) resolveSourceRoots(sources []string, sourceDir string) []string {
+	var roots []string
+	seen := make(map[string]bool)
+	addRoot := func(root string) {
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+
+	for _, source := range sources {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+		if mapped := parsers.MapReportPath(o.config, source); mapped != source {
+			source = mapped
+			if source == "" {
+				source = "." // Mapped onto the source directory itself.
+			}
+		}
+		normalized := strings.ReplaceAll(source, "\\", "/")
+
+		if filepath.IsAbs(source) || filepath.IsAbs(normalized) || sourceDir == "" {
+			if o.isDir(normalized) {
+				addRoot(filepath.Clean(filepath.FromSlash(normalized)))
+				continue
+			}
+		} else if candidate := filepath.Join(sourceDir, filepath.FromSlash(normalized)); o.isDir(candidate) {
+			// Relative sources (e.g. "." or "src") are relative to the checkout.
+			addRoot(candidate)
+			continue
+		}
+		if sourceDir == "" {
+			o.logger.Debug("Report source does not exist and there is no source directory to rebase it onto.", "source", source)
+			continue
+		}
+
+		rebased := sourceDir
+		parts := strings.Split(strings.Trim(normalized, "/"), "/")
+		if len(parts) > 0 && strings.HasSuffix(parts[0], ":") {
+			parts = parts[1:] // Drop a Windows drive letter.
+		}
+		for i := range parts {
+			candidate := filepath.Join(sourceDir, filepath.FromSlash(strings.Join(parts[i:], "/")))
+			if o.isDir(candidate) {
+				rebased = candidate
+				break
+			}
+		}
+		o.logger.Debug("Rebased report source onto the source directory.", "source", source, "rebased", rebased)
+		addRoot(filepath.Clean(rebased))
+	}
+	return roots
+}
+
+// findInSourceRoots joins a report path with each source root and returns the
+// first one that exists. Unlike utils.FindFileInSourceDirs, it never walks
+// directories, so a miss is cheap.
+func (o *processingOrchestrator) findInSourceRoots(path string, roots []string) (string, bool) {
+	if filepath.IsAbs(path) {
+		return "", false
+	}
+	for _, root := range roots {
+		candidate := filepath.Join(root, filepath.FromSlash(path))
+		if info, err := o.resolver.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isDir reports whether path is an existing directory.
+func (o *processingOrchestrator) isDir(path string) bool {
+	info, err := o.resolver.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// collectClassLines returns the lines of a class. Most tools (e.g. coverlet and
+// Cobertura itself) repeat the lines of every method in the class' <lines>
+// element, so method lines are only added when the class does not list them.
+func (o *processingOrchestrator) collectClassLines(classXML ClassXML) []LineXML {
+	listed := make(map[string]bool, len(classXML.Lines.Line))
+	for _, lineXML := range classXML.Lines.Line {
+		listed[lineXML.Number] = true
+	}
+
+	allLinesInClass := classXML.Lines.Line
+	for _, methodXML := range classXML.Methods.Method {
+		for _, lineXML := range methodXML.Lines.Line {
+			if !listed[lineXML.Number] {
+				allLinesInClass = append(allLinesInClass, lineXML)
+			}
+		}
+	}
+	return allLinesInClass
+}
+
+// processMethods converts the <method> elements of a class. Cobertura does not
+// record where a method is declared, so its boundaries are the first and last
+// of its lines. Methods without lines are skipped.
+func (o *processingOrchestrator) processMethods(methodsXML []MethodXML) []model.MethodMetrics {
+	var methods []model.MethodMetrics
+	for _, methodXML := range methodsXML {
+		startLine, endLine := 0, 0
+		for _, lineXML := range methodXML.Lines.Line {
+			lineNumber, err := strconv.Atoi(lineXML.Number)
+			if err != nil || lineNumber <= 0 {
+				continue
+			}
+			if startLine == 0 || lineNumber < startLine {
+				startLine = lineNumber
+			}
+			endLine = max(endLine, lineNumber)
+		}
+		if startLine == 0 || methodXML.Name == "" {
+			continue
+		}
+
+		method := model.MethodMetrics{
+			Name:      methodXML.Name + methodXML.Signature,
+			StartLine: startLine,
+			EndLine:   endLine,
+		}
+		if complexity := utils.ParseFloat(methodXML.Complexity); complexity > 0 {
+			cyclomatic := int(math.Round(complexity))
+			method.CyclomaticComplexity = &cyclomatic
+		}
+		methods = append(methods, method)
+	}
+	return methods
+}
+
 // mergeLinesIntoFile processes a list of XML line elements and merges their
 // data into a map of line metrics for a specific file.
 func (o *processingOrchestrator) mergeLinesIntoFile(lineMetrics map[int]model.LineMetrics, linesXML []LineXML) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/IgorBayerl/nanovision/filtering"
 	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/pathmapping"
 )
 
 type ParserResult struct {
@@ -15,28 +16,55 @@ type ParserResult struct {
 	SourceDirectory       string
 	Timestamp             *time.Time
 	ReportPattern         string
+	ReportFile            string // The report file this result was parsed from, set by the caller.
+
+	// CoverageMode is the counting mode recorded by the report, for formats
+	// that have one (e.g. "set", "count" or "atomic" for Go cover profiles).
+	// It is empty when the format does not record a mode. The tree keeps it
+	// per report, so that "set" hits are not shown as execution counts.
+	CoverageMode string
 }
 
 type FileCoverage struct {
 	Path  string
 	Lines map[int]model.LineMetrics
+
+	// Methods holds the method boundaries provided by the report itself, for
+	// formats that carry them. They are only used for files that no static
+	// analyzer supports; coverage is computed later from the merged lines.
+	Methods []model.MethodMetrics
 }
 
 type ParserConfig interface {
 	SourceDirectories() []string
 	FileFilters() filtering.IFilter
 	Logger() *slog.Logger
+	PathMapper() *pathmapping.Mapper // May be nil, which leaves report paths unchanged.
 }
 
 type SimpleParserConfig struct {
 	SrcDirs    []string
 	FileFilter filtering.IFilter
 	Log        *slog.Logger
+	Mapper     *pathmapping.Mapper
 }
 
-func (sc *SimpleParserConfig) SourceDirectories() []string    { return sc.SrcDirs }
-func (sc *SimpleParserConfig) FileFilters() filtering.IFilter { return sc.FileFilter }
-func (sc *SimpleParserConfig) Logger() *slog.Logger           { return sc.Log }
+func (sc *SimpleParserConfig) SourceDirectories() []string     { return sc.SrcDirs }
+func (sc *SimpleParserConfig) FileFilters() filtering.IFilter  { return sc.FileFilter }
+func (sc *SimpleParserConfig) Logger() *slog.Logger            { return sc.Log }
+func (sc *SimpleParserConfig) PathMapper() *pathmapping.Mapper { return sc.Mapper }
+
+// MapReportPath rewrites a path taken from a report (a file, a source root or
+// a working directory) with the path mappings of the config. Parsers map every
+// such path exactly once, before resolving it, and report the mapped file
+// paths, so later stages never map them again.
+func MapReportPath(config ParserConfig, reportPath string) string {
+	mapped := config.PathMapper().Map(reportPath)
+	if mapped != reportPath && config.Logger() != nil {
+		config.Logger().Debug("Mapped report path.", "path", reportPath, "mapped", mapped)
+	}
+	return mapped
+}
 
 type IParser interface {
 	Name() string
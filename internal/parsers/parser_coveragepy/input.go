@@ -0,0 +1,34 @@
+package parser_coveragepy
+
+// ReportJSON is the root of a "coverage json" report.
+type ReportJSON struct {
+	Meta  MetaJSON            `json:"meta"`
+	Files map[string]FileJSON `json:"files"`
+}
+
+// MetaJSON holds the report metadata. "version" is the coverage.py version,
+// "timestamp" is a local time without zone, e.g. "2024-01-01T12:00:00.123456".
+type MetaJSON struct {
+	Version        string `json:"version"`
+	Timestamp      string `json:"timestamp"`
+	BranchCoverage bool   `json:"branch_coverage"`
+	FormatVersion  int    `json:"format"`
+}
+
+// FileJSON is the coverage of a single file. Arcs are [from, to] line pairs;
+// a negative "to" line means the arc exits the code object.
+type FileJSON struct {
+	ExecutedLines    []int                   `json:"executed_lines"`
+	MissingLines     []int                   `json:"missing_lines"`
+	ExecutedBranches [][2]int                `json:"executed_branches"`
+	MissingBranches  [][2]int                `json:"missing_branches"`
+	Functions        map[string]FunctionJSON `json:"functions"`
+}
+
+// FunctionJSON is an entry of "functions" (report format 3 and later), keyed
+// by the qualified function name. The key "" holds the module-level code.
+type FunctionJSON struct {
+	ExecutedLines []int `json:"executed_lines"`
+	MissingLines  []int `json:"missing_lines"`
+	ExcludedLines []int `json:"excluded_lines"`
+}
@@ -0,0 +1,132 @@
+package parser_coveragepy
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// coveragePyTimestampLayout is the layout of "meta.timestamp" (Python's
+// datetime.isoformat() without a time zone).
+const coveragePyTimestampLayout = "2006-01-02T15:04:05.999999"
+
+// CoveragePyParser implements the parsers.IParser interface for the JSON reports
+// written by "coverage json". The XML reports of coverage.py are Cobertura
+// reports and are handled by the Cobertura parser.
+type CoveragePyParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewCoveragePyParser creates a new parser instance.
+func NewCoveragePyParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &CoveragePyParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *CoveragePyParser) Name() string {
+	return "CoveragePy"
+}
+
+// SupportsFile performs a fast check to see if this parser can handle the given file.
+// It verifies the file has a ".json" extension and that its root object has a
+// "meta" object with a "version" key, which Istanbul reports never have.
+func (p *CoveragePyParser) SupportsFile(filePath string) bool {
+	if !strings.HasSuffix(strings.ToLower(filePath), ".json") {
+		return false
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return false
+	}
+
+	// coverage.py writes "meta" first, but the top-level keys are walked so the
+	// check does not depend on key order.
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if token != "meta" {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return false
+			}
+			continue
+		}
+
+		var meta struct {
+			Version *string `json:"version"`
+		}
+		if err := decoder.Decode(&meta); err != nil {
+			return false
+		}
+		return meta.Version != nil
+	}
+	return false
+}
+
+// Parse unmarshals the coverage.py JSON report and delegates the conversion to a
+// flat list of FileCoverage objects to the processingOrchestrator.
+func (p *CoveragePyParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	rawReport, err := p.loadAndUnmarshalCoveragePyJSON(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/unmarshal coverage.py JSON from %s: %w", filePath, err)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processFiles(rawReport.Files)
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		Timestamp:             p.getReportTimestamp(rawReport.Meta.Timestamp, logger),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
+
+// ------ Helper Functions ------
+
+// getReportTimestamp parses "meta.timestamp", which is recorded in the local
+// time of the machine that produced the report.
+func (p *CoveragePyParser) getReportTimestamp(rawTimestamp string, logger *slog.Logger) *time.Time {
+	if rawTimestamp == "" {
+		return nil
+	}
+	t, err := time.ParseInLocation(coveragePyTimestampLayout, rawTimestamp, time.Local)
+	if err != nil {
+		logger.Warn("Failed to parse coverage.py timestamp", "timestamp", rawTimestamp, "error", err)
+		return nil
+	}
+	return &t
+}
+
+// loadAndUnmarshalCoveragePyJSON reads and unmarshals the coverage.py JSON file.
+func (p *CoveragePyParser) loadAndUnmarshalCoveragePyJSON(path string) (*ReportJSON, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var rawReport ReportJSON
+	if err := json.Unmarshal(bytes, &rawReport); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &rawReport, nil
+}
@@ -0,0 +1,161 @@
+package parser_coveragepy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_coveragepy"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoveragePyParser_Parse(t *testing.T) {
+	const reportFileName = "coverage.json"
+	const sourceDir = "/app"
+	const sourceFilePath = "pkg/calc.py" // This is the path inside the report
+	const resolvedSourcePath = "/app/pkg/calc.py"
+
+	testCases := []struct {
+		name          string
+		reportContent string
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name: "Golden Path - Lines, excluded lines and branch arcs",
+			reportContent: `{
+  "meta": {"format": 3, "version": "7.5.1", "timestamp": "2024-01-01T12:00:00.123456", "branch_coverage": true, "show_contexts": false},
+  "files": {
+    "pkg/calc.py": {
+      "executed_lines": [1, 2, 3, 4, 8],
+      "missing_lines": [6],
+      "excluded_lines": [10, 11],
+      "executed_branches": [[3, 4], [4, -2]],
+      "missing_branches": [[3, 6], [4, 8]],
+      "functions": {
+        "sign": {"executed_lines": [3, 4], "missing_lines": [6], "excluded_lines": []},
+        "": {"executed_lines": [1, 2, 8], "missing_lines": [], "excluded_lines": [10, 11]}
+      }
+    }
+  },
+  "totals": {"covered_lines": 5, "num_statements": 6}
+}`,
+			sourceFiles: map[string]string{
+				resolvedSourcePath: `# Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "CoveragePy", result.ParserName)
+				require.NotNil(t, result.Timestamp)
+				assert.Equal(t, 2024, result.Timestamp.Year())
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, sourceFilePath, fileCov.Path)
+				require.Len(t, fileCov.Lines, 6)
+
+				assert.Equal(t, 1, fileCov.Lines[1].Hits)
+				assert.Equal(t, 0, fileCov.Lines[6].Hits)
+				assert.NotContains(t, fileCov.Lines, 10, "Excluded lines should be left out")
+				assert.NotContains(t, fileCov.Lines, 11)
+
+				assert.Equal(t, 2, fileCov.Lines[3].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[3].CoveredBranches)
+				assert.Equal(t, 2, fileCov.Lines[4].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[4].CoveredBranches)
+				assert.Equal(t, 0, fileCov.Lines[8].TotalBranches)
+
+				require.Len(t, fileCov.Methods, 1, "Module-level code should not become a method")
+				assert.Equal(t, "sign", fileCov.Methods[0].Name)
+				assert.Equal(t, 3, fileCov.Methods[0].StartLine)
+				assert.Equal(t, 6, fileCov.Methods[0].EndLine)
+			},
+		},
+		{
+			name: "Line-only report with an unresolved file",
+			reportContent: `{
+  "meta": {"version": "6.5.0", "timestamp": "2023-05-01T08:30:00.000001", "branch_coverage": false},
+  "files": {
+    "scripts/tool.py": {"executed_lines": [1], "missing_lines": [2], "excluded_lines": []}
+  }
+}`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, "scripts/tool.py", fileCov.Path)
+				assert.Equal(t, 1, fileCov.Lines[1].Hits)
+				assert.Equal(t, 0, fileCov.Lines[2].Hits)
+				assert.Empty(t, fileCov.Methods)
+
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, "scripts/tool.py", result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name:          "Report is logically empty (no files)",
+			reportContent: `{"meta": {"version": "7.4.0"}, "files": {}}`,
+			sourceFiles:   map[string]string{},
+			sourceDirs:    []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Nil(t, result.Timestamp)
+				assert.Empty(t, result.FileCoverage)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, reportFileName)
+			err := os.WriteFile(reportPath, []byte(tc.reportContent), 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_coveragepy.NewCoveragePyParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
+
+func TestCoveragePyParser_SupportsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	parser := parser_coveragepy.NewCoveragePyParser(utils.NewSourceResolver(testutil.NewMockFilesystem("unix"), nil))
+
+	coveragePyFile := filepath.Join(tmpDir, "coverage.json")
+	require.NoError(t, os.WriteFile(coveragePyFile, []byte(`{"files": {}, "meta": {"version": "7.4.0"}}`), 0644))
+
+	istanbulFile := filepath.Join(tmpDir, "coverage-final.json")
+	require.NoError(t, os.WriteFile(istanbulFile, []byte(`{"/app/a.js": {"path": "/app/a.js", "statementMap": {}}}`), 0644))
+
+	metaWithoutVersionFile := filepath.Join(tmpDir, "package.json")
+	require.NoError(t, os.WriteFile(metaWithoutVersionFile, []byte(`{"meta": {"name": "x"}}`), 0644))
+
+	assert.True(t, parser.SupportsFile(coveragePyFile))
+	assert.False(t, parser.SupportsFile(istanbulFile))
+	assert.False(t, parser.SupportsFile(metaWithoutVersionFile))
+}
@@ -0,0 +1,127 @@
+package parser_coveragepy
+
+import (
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// processingOrchestrator is responsible for converting the raw coverage.py data
+// into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// processFiles is the main entry point for the orchestrator. Files are returned
+// sorted by path.
+func (o *processingOrchestrator) processFiles(files map[string]FileJSON) ([]parsers.FileCoverage, []string) {
+	reportPaths := make([]string, 0, len(files))
+	for reportPath := range files {
+		reportPaths = append(reportPaths, reportPath)
+	}
+	sort.Strings(reportPaths)
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+	for _, reportPath := range reportPaths {
+		filePath := parsers.MapReportPath(o.config, filepath.ToSlash(reportPath))
+
+		if _, err := o.resolver.Resolve(filePath, []string{sourceDir}); err != nil {
+			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
+			unresolvedFiles = append(unresolvedFiles, filePath)
+		}
+
+		fileJSON := files[reportPath]
+		finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
+			Path:    filePath,
+			Lines:   o.processLines(fileJSON),
+			Methods: o.processFunctions(fileJSON.Functions),
+		})
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
+
+// processLines maps the line lists of a file onto line metrics. coverage.py
+// does not count executions, so executed lines get a single hit. Excluded lines
+// are left out, like the lines other formats do not list, so that merging with
+// a report that covers them keeps its counts.
+func (o *processingOrchestrator) processLines(fileJSON FileJSON) map[int]model.LineMetrics {
+	lineMetrics := make(map[int]model.LineMetrics)
+	for _, lineNumber := range fileJSON.MissingLines {
+		lineMetrics[lineNumber] = model.LineMetrics{Hits: 0}
+	}
+	for _, lineNumber := range fileJSON.ExecutedLines {
+		lineMetrics[lineNumber] = model.LineMetrics{Hits: 1}
+	}
+
+	// Every arc leaving a branch line is one branch of that line.
+	o.applyArcs(lineMetrics, fileJSON.ExecutedBranches, true)
+	o.applyArcs(lineMetrics, fileJSON.MissingBranches, false)
+
+	return lineMetrics
+}
+
+// applyArcs adds one branch to the source line of every arc.
+func (o *processingOrchestrator) applyArcs(lineMetrics map[int]model.LineMetrics, arcs [][2]int, covered bool) {
+	for _, arc := range arcs {
+		metric, ok := lineMetrics[arc[0]]
+		if !ok {
+			continue // Branches are only meaningful on coverable lines.
+		}
+		metric.TotalBranches++
+		if covered {
+			metric.CoveredBranches++
+		}
+		lineMetrics[arc[0]] = metric
+	}
+}
+
+// processFunctions converts the "functions" regions into method boundaries.
+// coverage.py only lists the lines of each function, so a method spans from
+// its first to its last listed line.
+func (o *processingOrchestrator) processFunctions(functions map[string]FunctionJSON) []model.MethodMetrics {
+	var methods []model.MethodMetrics
+	for name, function := range functions {
+		if name == "" {
+			continue // Module-level code is not a method.
+		}
+		lines := slices.Concat(function.ExecutedLines, function.MissingLines, function.ExcludedLines)
+		if len(lines) == 0 {
+			continue
+		}
+		methods = append(methods, model.MethodMetrics{
+			Name:      name,
+			StartLine: slices.Min(lines),
+			EndLine:   slices.Max(lines),
+		})
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].StartLine != methods[j].StartLine {
+			return methods[i].StartLine < methods[j].StartLine
+		}
+		return methods[i].Name < methods[j].Name
+	})
+	return methods
+}
@@ -10,17 +10,18 @@ import (
 
 	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
 )
 
 // GCovParser implements the parsers.IParser interface for gcov reports.
 type GCovParser struct {
-	fileReader filereader.Reader
+	resolver *utils.SourceResolver
 }
 
 // NewGCovParser creates a new parser instance.
-func NewGCovParser(fileReader filereader.Reader) parsers.IParser {
+func NewGCovParser(resolver *utils.SourceResolver) parsers.IParser {
 	return &GCovParser{
-		fileReader: fileReader,
+		resolver: resolver,
 	}
 }
 
@@ -57,7 +58,7 @@ func (p *GCovParser) Parse(filePath string, config parsers.ParserConfig) (*parse
 		return nil, fmt.Errorf("failed to read gcov file %s: %w", filePath, err)
 	}
 
-	orchestrator := newProcessingOrchestrator(p.fileReader, config, logger)
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
 
 	// Since a gcov file maps to a single source file, the orchestrator returns
 	// a single FileCoverage object or nil if the file is invalid/unresolved.
@@ -8,6 +8,7 @@ import (
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_gcov"
 	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -135,7 +136,7 @@ branch  1 never executed
 			}
 
 			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
-			parser := parser_gcov.NewGCovParser(mockFS)
+			parser := parser_gcov.NewGCovParser(utils.NewSourceResolver(mockFS, nil))
 
 			// Act
 			result, err := parser.Parse(reportPath, mockConfig)
@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/utils"
@@ -20,16 +19,16 @@ var (
 )
 
 type processingOrchestrator struct {
-	fileReader filereader.Reader
-	config     parsers.ParserConfig
-	logger     *slog.Logger
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
 }
 
-func newProcessingOrchestrator(fileReader filereader.Reader, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
 	return &processingOrchestrator{
-		fileReader: fileReader,
-		config:     config,
-		logger:     logger,
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
 	}
 }
 
@@ -43,7 +42,7 @@ func (o *processingOrchestrator) processLines(lines []string) (*parsers.FileCove
 		return nil, nil, fmt.Errorf("invalid gcov format: first line does not contain '0:Source:'")
 	}
 
-	sourceFilePathFromReport := filepath.ToSlash(strings.TrimSpace(strings.SplitN(firstLine, "0:Source:", 2)[1]))
+	sourceFilePathFromReport := parsers.MapReportPath(o.config, filepath.ToSlash(strings.TrimSpace(strings.SplitN(firstLine, "0:Source:", 2)[1])))
 	sourceDirs := o.config.SourceDirectories()
 
 	// We will pass the original, potentially absolute path to the builder.
@@ -52,7 +51,7 @@ func (o *processingOrchestrator) processLines(lines []string) (*parsers.FileCove
 
 	var unresolvedFiles []string
 	// Pass the logger from the orchestrator into the find utility
-	if _, err := utils.FindFileInSourceDirs(sourceFilePathFromReport, sourceDirs, o.fileReader, o.logger); err != nil {
+	if _, err := o.resolver.Resolve(sourceFilePathFromReport, sourceDirs); err != nil {
 		o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", sourceFilePathFromReport, "error", err)
 		unresolvedFiles = append(unresolvedFiles, sourceFilePathFromReport)
 	}
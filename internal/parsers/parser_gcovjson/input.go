@@ -0,0 +1,46 @@
+package parser_gcovjson
+
+// ReportJSON is the root of a "gcov --json-format" report, which describes
+// every source file that contributed to one .gcda data file.
+type ReportJSON struct {
+	FormatVersion           string     `json:"format_version"`
+	GCCVersion              string     `json:"gcc_version"`
+	CurrentWorkingDirectory string     `json:"current_working_directory"` // The directory relative file paths start from.
+	DataFile                string     `json:"data_file"`
+	Files                   []FileJSON `json:"files"`
+}
+
+// FileJSON is the coverage of a single source file.
+type FileJSON struct {
+	File      string         `json:"file"`
+	Functions []FunctionJSON `json:"functions"`
+	Lines     []LineJSON     `json:"lines"`
+}
+
+// FunctionJSON describes a function and its exact boundaries.
+type FunctionJSON struct {
+	Name           string `json:"name"`
+	DemangledName  string `json:"demangled_name"`
+	StartLine      int    `json:"start_line"`
+	EndLine        int    `json:"end_line"`
+	ExecutionCount int64  `json:"execution_count"`
+}
+
+// LineJSON is the coverage of a single line. A line can be listed several
+// times, once for every function (e.g. template instantiation) it belongs to.
+// UnexecutedBlock is set when one of the basic blocks of the line never ran,
+// even if others did.
+type LineJSON struct {
+	LineNumber      int          `json:"line_number"`
+	FunctionName    string       `json:"function_name"`
+	Count           int64        `json:"count"`
+	UnexecutedBlock bool         `json:"unexecuted_block"`
+	Branches        []BranchJSON `json:"branches"`
+}
+
+// BranchJSON is one outgoing branch of a line.
+type BranchJSON struct {
+	Count       int64 `json:"count"`
+	Fallthrough bool  `json:"fallthrough"`
+	Throw       bool  `json:"throw"`
+}
@@ -0,0 +1,136 @@
+package parser_gcovjson
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// GCovJSONParser implements the parsers.IParser interface for the JSON
+// intermediate format of gcov ("gcov --json-format"), usually written as
+// gzip-compressed ".gcov.json.gz" files.
+type GCovJSONParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewGCovJSONParser creates a new parser instance.
+func NewGCovJSONParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &GCovJSONParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *GCovJSONParser) Name() string {
+	return "GCovJSON"
+}
+
+// SupportsFile performs a fast check to see if this parser can handle the given file.
+// It verifies the file has a ".json" or ".json.gz" extension and that its root
+// object declares a "gcc_version", which gcov writes among its first keys.
+func (p *GCovJSONParser) SupportsFile(filePath string) bool {
+	lowerPath := strings.ToLower(filePath)
+	if !strings.HasSuffix(lowerPath, ".json") && !strings.HasSuffix(lowerPath, ".json.gz") {
+		return false
+	}
+
+	reader, closer, err := openMaybeGzipped(filePath)
+	if err != nil {
+		return false
+	}
+	defer closer.Close()
+
+	decoder := json.NewDecoder(reader)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return false
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if token == "gcc_version" {
+			return true
+		}
+		if token == "files" {
+			return false // The version always precedes the (large) file list.
+		}
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return false
+		}
+	}
+	return false
+}
+
+// Parse decompresses (if needed) and unmarshals the gcov JSON report and
+// delegates the conversion to a flat list of FileCoverage objects to the
+// processingOrchestrator.
+func (p *GCovJSONParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	rawReport, err := p.loadAndUnmarshalGCovJSON(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/unmarshal gcov JSON from %s: %w", filePath, err)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processFiles(rawReport.Files, rawReport.CurrentWorkingDirectory)
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
+
+// ------ Helper Functions ------
+
+// loadAndUnmarshalGCovJSON reads and unmarshals the gcov JSON file.
+func (p *GCovJSONParser) loadAndUnmarshalGCovJSON(path string) (*ReportJSON, error) {
+	reader, closer, err := openMaybeGzipped(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	var rawReport ReportJSON
+	if err := json.NewDecoder(reader).Decode(&rawReport); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	return &rawReport, nil
+}
+
+// openMaybeGzipped opens a file and transparently decompresses it when it
+// starts with the gzip magic bytes, regardless of its extension.
+func openMaybeGzipped(path string) (io.Reader, io.Closer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open file: %w", err)
+	}
+
+	buffered := bufio.NewReader(f)
+	magic, err := buffered.Peek(2)
+	if err != nil && err != io.EOF {
+		f.Close()
+		return nil, nil, fmt.Errorf("read file: %w", err)
+	}
+	if len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return buffered, f, nil
+	}
+
+	gzipReader, err := gzip.NewReader(buffered)
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("open gzip stream: %w", err)
+	}
+	return gzipReader, f, nil
+}
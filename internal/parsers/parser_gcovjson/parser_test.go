@@ -0,0 +1,237 @@
+package parser_gcovjson_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_gcovjson"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/IgorBayerl/nanovision/pathmapping"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goldenReport = `{
+  "format_version": "2",
+  "gcc_version": "13.2.0",
+  "current_working_directory": "/app/build",
+  "data_file": "main.gcda",
+  "files": [
+    {
+      "file": "src/main.cpp",
+      "functions": [
+        {"blocks": 6, "blocks_executed": 5, "demangled_name": "sign(int)", "end_column": 1, "end_line": 9, "execution_count": 3, "name": "_Z4signi", "start_column": 5, "start_line": 3}
+      ],
+      "lines": [
+        {"branches": [], "count": 3, "line_number": 3, "unexecuted_block": false, "function_name": "_Z4signi"},
+        {"branches": [{"count": 1, "throw": false, "fallthrough": true}, {"count": 2, "throw": false, "fallthrough": false}], "count": 3, "line_number": 4, "unexecuted_block": false, "function_name": "_Z4signi"},
+        {"branches": [], "count": 1, "line_number": 5, "unexecuted_block": false, "function_name": "_Z4signi"},
+        {"branches": [{"count": 0, "throw": false, "fallthrough": true}, {"count": 2, "throw": false, "fallthrough": false}], "count": 2, "line_number": 6, "unexecuted_block": true, "function_name": "_Z4signi"},
+        {"branches": [], "count": 0, "line_number": 7, "unexecuted_block": true, "function_name": "_Z4signi"}
+      ]
+    },
+    {
+      "file": "include/box.h",
+      "functions": [
+        {"demangled_name": "Box<int>::get() const", "end_line": 4, "name": "_ZNK3BoxIiE3getEv", "start_line": 4},
+        {"demangled_name": "Box<long>::get() const", "end_line": 4, "name": "_ZNK3BoxIlE3getEv", "start_line": 4}
+      ],
+      "lines": [
+        {"branches": [{"count": 0}, {"count": 1}], "count": 2, "line_number": 4, "unexecuted_block": false, "function_name": "_ZNK3BoxIiE3getEv"},
+        {"branches": [{"count": 1}, {"count": 0}], "count": 1, "line_number": 4, "unexecuted_block": false, "function_name": "_ZNK3BoxIlE3getEv"}
+      ]
+    }
+  ]
+}`
+
+func TestGCovJSONParser_Parse(t *testing.T) {
+	const sourceDir = "/app"
+
+	testCases := []struct {
+		name          string
+		reportName    string
+		reportContent string
+		gzipped       bool
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name:          "Golden Path - Gzipped report with several files",
+			reportName:    "main.gcda.gcov.json.gz",
+			reportContent: goldenReport,
+			gzipped:       true,
+			sourceFiles: map[string]string{
+				"/app/src/main.cpp":  `// Dummy content`,
+				"/app/include/box.h": `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "GCovJSON", result.ParserName)
+
+				require.Len(t, result.FileCoverage, 2)
+				header, main := result.FileCoverage[0], result.FileCoverage[1]
+				assert.Equal(t, "include/box.h", header.Path)
+				assert.Equal(t, "src/main.cpp", main.Path)
+
+				require.Len(t, main.Lines, 5)
+				assert.Equal(t, 3, main.Lines[3].Hits)
+				assert.Equal(t, 0, main.Lines[7].Hits)
+				assert.Equal(t, 2, main.Lines[4].TotalBranches)
+				assert.Equal(t, 2, main.Lines[4].CoveredBranches)
+				assert.Equal(t, 2, main.Lines[6].TotalBranches)
+				assert.Equal(t, 1, main.Lines[6].CoveredBranches)
+				assert.True(t, main.Lines[6].UnexecutedBlock)
+				assert.Zero(t, main.Lines[6].TotalStatements, "gcov does not count statements")
+				assert.False(t, main.Lines[3].UnexecutedBlock)
+
+				require.Len(t, main.Methods, 1)
+				assert.Equal(t, "sign(int)", main.Methods[0].Name)
+				assert.Equal(t, 3, main.Methods[0].StartLine)
+				assert.Equal(t, 9, main.Methods[0].EndLine)
+
+				require.Len(t, header.Lines, 1)
+				assert.Equal(t, 3, header.Lines[4].Hits, "Instances of the same line should be summed")
+				assert.Equal(t, 2, header.Lines[4].TotalBranches, "Instances of the same branch should not be counted twice")
+				assert.Equal(t, 2, header.Lines[4].CoveredBranches)
+				assert.Len(t, header.Methods, 2)
+			},
+		},
+		{
+			name:          "Uncompressed report with an unresolved file",
+			reportName:    "main.gcov.json",
+			reportContent: goldenReport,
+			sourceFiles: map[string]string{
+				"/app/src/main.cpp": `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 2)
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, "include/box.h", result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name:       "Paths relative to the working directory of the build",
+			reportName: "main.gcov.json",
+			reportContent: `{"format_version": "2", "current_working_directory": "/app/build", "files": [
+				{"file": "../src/main.cpp", "functions": [], "lines": [{"line_number": 1, "count": 1, "branches": []}]},
+				{"file": "src/util.cpp", "functions": [], "lines": [{"line_number": 1, "count": 0, "branches": []}]}
+			]}`,
+			sourceFiles: map[string]string{
+				"/app/src/main.cpp": `// Dummy content`,
+				"/app/src/util.cpp": `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				require.Len(t, result.FileCoverage, 2)
+				assert.Equal(t, "/app/src/main.cpp", result.FileCoverage[0].Path, "Paths found in the working directory should be reported resolved")
+				assert.Equal(t, "src/util.cpp", result.FileCoverage[1].Path, "Other paths should fall back to the source directory")
+			},
+		},
+		{
+			name:          "Corrupted gzip stream",
+			reportName:    "broken.gcov.json.gz",
+			reportContent: "\x1f\x8b\x08garbage",
+			sourceFiles:   map[string]string{},
+			sourceDirs:    []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, tc.reportName)
+			content := []byte(tc.reportContent)
+			if tc.gzipped {
+				content = gzipBytes(t, content)
+			}
+			err := os.WriteFile(reportPath, content, 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_gcovjson.NewGCovJSONParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
+
+func TestGCovJSONParser_Parse_PathMappings(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "main.gcov.json")
+	require.NoError(t, os.WriteFile(reportPath, []byte(goldenReport), 0644))
+
+	mockFS := testutil.NewMockFilesystem("unix")
+	mockFS.AddFile("/app/backend/build/src/main.cpp", "int sign(int x);")
+	mockFS.AddFile("/app/backend/build/include/box.h", "template <typename T> struct Box;")
+	// The mapped prefix starts with the original one, so mapping a path twice
+	// would point below /app/backend/backend.
+	mapper, err := pathmapping.New([]pathmapping.Rule{{From: "/app", To: "/app/backend"}})
+	require.NoError(t, err)
+	config := testutil.NewTestConfig([]string{"/app/backend"})
+	config.(*testutil.MockParserConfig).Mapper = mapper
+
+	result, err := parser_gcovjson.NewGCovJSONParser(utils.NewSourceResolver(mockFS, nil)).Parse(reportPath, config)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.UnresolvedSourceFiles)
+	var paths []string
+	for _, fileCoverage := range result.FileCoverage {
+		paths = append(paths, fileCoverage.Path)
+	}
+	assert.Equal(t, []string{"/app/backend/build/include/box.h", "/app/backend/build/src/main.cpp"}, paths,
+		"The working directory should be mapped once and the files resolved against it")
+}
+
+func TestGCovJSONParser_SupportsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	parser := parser_gcovjson.NewGCovJSONParser(utils.NewSourceResolver(testutil.NewMockFilesystem("unix"), nil))
+
+	gzippedFile := filepath.Join(tmpDir, "main.gcda.gcov.json.gz")
+	require.NoError(t, os.WriteFile(gzippedFile, gzipBytes(t, []byte(goldenReport)), 0644))
+
+	plainFile := filepath.Join(tmpDir, "main.gcov.json")
+	require.NoError(t, os.WriteFile(plainFile, []byte(goldenReport), 0644))
+
+	llvmFile := filepath.Join(tmpDir, "coverage.json")
+	require.NoError(t, os.WriteFile(llvmFile, []byte(`{"data":[],"type":"llvm.coverage.json.export","version":"2.0.1"}`), 0644))
+
+	assert.True(t, parser.SupportsFile(gzippedFile))
+	assert.True(t, parser.SupportsFile(plainFile))
+	assert.False(t, parser.SupportsFile(llvmFile))
+}
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,191 @@
+package parser_gcovjson
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// processingOrchestrator is responsible for converting the raw gcov JSON data
+// into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// lineAccumulator merges the entries of a line that is listed once per function
+// it belongs to. Counts are summed, like the per-line totals of the textual
+// gcov output, and a branch is covered when any instance took it.
+type lineAccumulator struct {
+	count           int64
+	unexecutedBlock bool
+	branches        []bool
+}
+
+// processFiles is the main entry point for the orchestrator. A single report
+// lists every source file (headers included) that contributed to one object
+// file, and each of them becomes its own FileCoverage entry, sorted by path.
+//
+// Paths are reported as passed to the compiler, relative to workingDir, the
+// working directory of the build. They are resolved against it first and
+// against the source directory when the build ran elsewhere.
+func (o *processingOrchestrator) processFiles(files []FileJSON, workingDir string) ([]parsers.FileCoverage, []string) {
+	linesByFile := make(map[string]map[int]*lineAccumulator)
+	methodsByFile := make(map[string][]model.MethodMetrics)
+
+	for _, fileJSON := range files {
+		filePath := filepath.ToSlash(fileJSON.File)
+		if filePath == "" {
+			continue
+		}
+		if linesByFile[filePath] == nil {
+			linesByFile[filePath] = make(map[int]*lineAccumulator)
+		}
+		o.accumulateLines(linesByFile[filePath], fileJSON.Lines)
+		methodsByFile[filePath] = append(methodsByFile[filePath], o.processFunctions(fileJSON.Functions)...)
+	}
+
+	filePaths := make([]string, 0, len(linesByFile))
+	for filePath := range linesByFile {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	// A working directory rewritten by the path mappings to a relative path
+	// is relative to the source directory.
+	if mapped := parsers.MapReportPath(o.config, workingDir); workingDir != "" && mapped != workingDir {
+		workingDir = mapped
+		if !filepath.IsAbs(filepath.FromSlash(workingDir)) {
+			workingDir = filepath.Join(sourceDir, filepath.FromSlash(workingDir))
+		}
+	}
+
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+	for _, reportPath := range filePaths {
+		// A file path rewritten by the path mappings is relative to the
+		// source directory rather than to the working directory.
+		filePath := parsers.MapReportPath(o.config, reportPath)
+		fileWorkingDir := workingDir
+		if filePath != reportPath {
+			fileWorkingDir = ""
+		}
+		reportedPath := filePath
+		if resolvedPath, ok := o.findInWorkingDir(filePath, fileWorkingDir); ok {
+			// Reporting the resolved path spares later stages from searching
+			// the source directory for a file the report already located.
+			reportedPath = resolvedPath
+		} else if _, err := o.resolver.Resolve(filePath, []string{sourceDir}); err != nil {
+			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
+			unresolvedFiles = append(unresolvedFiles, filePath)
+		}
+
+		methods := methodsByFile[reportPath]
+		sort.SliceStable(methods, func(i, j int) bool {
+			return methods[i].StartLine < methods[j].StartLine
+		})
+		finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
+			Path:    reportedPath,
+			Lines:   finalizeLines(linesByFile[reportPath]),
+			Methods: methods,
+		})
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
+
+// findInWorkingDir joins a relative report path with the working directory of
+// the build and returns it when the file exists there.
+func (o *processingOrchestrator) findInWorkingDir(filePath, workingDir string) (string, bool) {
+	if workingDir == "" || filepath.IsAbs(filepath.FromSlash(filePath)) {
+		return "", false
+	}
+	candidate := filepath.Join(workingDir, filepath.FromSlash(filePath))
+	if info, err := o.resolver.Stat(candidate); err == nil && !info.IsDir() {
+		return candidate, true
+	}
+	return "", false
+}
+
+// accumulateLines merges the line entries of a file into its accumulators.
+func (o *processingOrchestrator) accumulateLines(accumulators map[int]*lineAccumulator, lines []LineJSON) {
+	for _, lineJSON := range lines {
+		if lineJSON.LineNumber <= 0 {
+			continue
+		}
+		acc, ok := accumulators[lineJSON.LineNumber]
+		if !ok {
+			acc = &lineAccumulator{}
+			accumulators[lineJSON.LineNumber] = acc
+		}
+
+		acc.count += max(lineJSON.Count, 0)
+		acc.unexecutedBlock = acc.unexecutedBlock || lineJSON.UnexecutedBlock
+		for i, branch := range lineJSON.Branches {
+			if i >= len(acc.branches) {
+				acc.branches = append(acc.branches, false)
+			}
+			acc.branches[i] = acc.branches[i] || branch.Count > 0
+		}
+	}
+}
+
+// processFunctions converts the function records of a file into methods. gcov
+// reports exact start and end lines, so no boundary inference is needed.
+func (o *processingOrchestrator) processFunctions(functions []FunctionJSON) []model.MethodMetrics {
+	var methods []model.MethodMetrics
+	for _, function := range functions {
+		name := function.DemangledName
+		if name == "" {
+			name = function.Name
+		}
+		if name == "" || function.StartLine <= 0 {
+			continue
+		}
+		methods = append(methods, model.MethodMetrics{
+			Name:      name,
+			StartLine: function.StartLine,
+			EndLine:   max(function.EndLine, function.StartLine),
+		})
+	}
+	return methods
+}
+
+// finalizeLines converts the accumulators of a file into line metrics. gcov
+// does not count the blocks of a line, only whether one of them never ran, so
+// that is kept as a flag rather than as statement counts.
+func finalizeLines(accumulators map[int]*lineAccumulator) map[int]model.LineMetrics {
+	lineMetrics := make(map[int]model.LineMetrics, len(accumulators))
+	for lineNumber, acc := range accumulators {
+		metric := model.LineMetrics{
+			Hits:            int(acc.count),
+			TotalBranches:   len(acc.branches),
+			UnexecutedBlock: acc.unexecutedBlock,
+		}
+		for _, taken := range acc.branches {
+			if taken {
+				metric.CoveredBranches++
+			}
+		}
+		lineMetrics[lineNumber] = metric
+	}
+	return lineMetrics
+}
@@ -0,0 +1,417 @@
+package parser_gocover
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// This file decodes the binary coverage data that binaries built with
+// "go build -cover" write into GOCOVERDIR (Go 1.20+). A directory holds one
+// "covmeta.<hash>" file per instrumented binary, describing every coverable
+// unit, and one "covcounters.<hash>.<pid>.<time>" file per run of that binary.
+// The layouts mirror the Go toolchain's internal/coverage package.
+
+const (
+	covMetaFilePrefix    = "covmeta."
+	covCounterFilePrefix = "covcounters."
+
+	metaFileHeaderSize    = 56 // MetaFileHeader
+	metaSymbolHeaderSize  = 44 // MetaSymbolHeader (CovMetaHeaderSize)
+	counterFileHeaderSize = 32 // CounterFileHeader
+	counterFileFooterSize = 16 // CounterFileFooter
+
+	counterModeSet         = 1
+	counterGranularityFunc = 2
+
+	counterFlavorRaw     = 1
+	counterFlavorULeb128 = 2
+)
+
+var (
+	// counterModeNames maps the counter modes of a meta-data file to the
+	// names used by "mode:" lines of textual profiles.
+	counterModeNames = map[uint8]string{1: "set", 2: "count", 3: "atomic"}
+
+	covMetaMagic    = []byte{0x00, 'c', 'v', 'm'}
+	covCounterMagic = []byte{0x00, 'c', 'w', 'm'}
+)
+
+// coverMetaFile is the decoded content of a covmeta file.
+type coverMetaFile struct {
+	hash        [16]byte
+	counterMode uint8
+	perFunc     bool
+	packages    [][]coverFunc // indexed by package, then by function
+}
+
+// coverFunc is a single instrumented function and its coverable units.
+type coverFunc struct {
+	srcFile string
+	units   []coverUnit
+}
+
+// coverUnit is a basic block of a function, as in a textual profile line.
+type coverUnit struct {
+	stLine, stCol, enLine, enCol, numStmts uint32
+}
+
+// funcKey identifies the counters of a function within a binary.
+type funcKey struct {
+	pkgIdx, funcIdx uint32
+}
+
+// isGoCoverDir reports whether dirPath is a GOCOVERDIR, i.e. a directory
+// that contains at least one meta-data file.
+func isGoCoverDir(dirPath string) bool {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), covMetaFilePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadGoCoverDir decodes every meta-data file in dirPath together with its
+// counter files and returns the same blocks a textual profile would contain.
+// Units of functions that never ran are reported with a hit count of 0, and
+// blocks listed by several meta-data files are merged.
+func loadGoCoverDir(dirPath string) (*GoCoverProfile, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, fmt.Errorf("read coverage directory: %w", err)
+	}
+
+	var metaFiles, counterFiles []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(entry.Name(), covMetaFilePrefix):
+			metaFiles = append(metaFiles, filepath.Join(dirPath, entry.Name()))
+		case strings.HasPrefix(entry.Name(), covCounterFilePrefix):
+			counterFiles = append(counterFiles, filepath.Join(dirPath, entry.Name()))
+		}
+	}
+	if len(metaFiles) == 0 {
+		return nil, fmt.Errorf("no %s* files found in %s", covMetaFilePrefix, dirPath)
+	}
+	sort.Strings(metaFiles)
+
+	countersByMeta := make(map[[16]byte][]string)
+	for _, counterFile := range counterFiles {
+		content, err := os.ReadFile(counterFile)
+		if err != nil {
+			return nil, fmt.Errorf("read counter file %s: %w", counterFile, err)
+		}
+		if len(content) < counterFileHeaderSize || !bytes.Equal(content[:4], covCounterMagic) {
+			return nil, fmt.Errorf("counter file %s: invalid magic", counterFile)
+		}
+		var metaHash [16]byte
+		copy(metaHash[:], content[8:24])
+		countersByMeta[metaHash] = append(countersByMeta[metaHash], counterFile)
+	}
+
+	profile := &GoCoverProfile{}
+	for _, metaPath := range metaFiles {
+		meta, err := decodeMetaFile(metaPath)
+		if err != nil {
+			return nil, fmt.Errorf("meta-data file %s: %w", metaPath, err)
+		}
+
+		mode, ok := counterModeNames[meta.counterMode]
+		if !ok {
+			return nil, fmt.Errorf("meta-data file %s: unsupported counter mode %d", metaPath, meta.counterMode)
+		}
+		if profile.Mode != "" && profile.Mode != mode {
+			return nil, fmt.Errorf("counter mode clash: %q in %s, %q elsewhere", mode, metaPath, profile.Mode)
+		}
+		profile.Mode = mode
+
+		counters := make(map[funcKey][]uint32)
+		for _, counterPath := range countersByMeta[meta.hash] {
+			if err := decodeCounterFile(counterPath, meta.counterMode, counters); err != nil {
+				return nil, fmt.Errorf("counter file %s: %w", counterPath, err)
+			}
+		}
+
+		profile.Blocks = append(profile.Blocks, meta.blocks(counters)...)
+	}
+
+	// Binaries that cover the same package each list its blocks.
+	profile.Blocks = mergeDuplicateBlocks(profile.Blocks, profile.Mode)
+	return profile, nil
+}
+
+// blocks joins the units of a meta-data file with their merged counters.
+func (m *coverMetaFile) blocks(counters map[funcKey][]uint32) []GoCoverProfileBlock {
+	var blocks []GoCoverProfileBlock
+	for pkgIdx, funcs := range m.packages {
+		for funcIdx, fn := range funcs {
+			funcCounters := counters[funcKey{uint32(pkgIdx), uint32(funcIdx)}]
+			for unitIdx, unit := range fn.units {
+				var count uint32
+				switch {
+				case m.perFunc && len(funcCounters) > 0:
+					count = funcCounters[0]
+				case unitIdx < len(funcCounters):
+					count = funcCounters[unitIdx]
+				}
+				blocks = append(blocks, GoCoverProfileBlock{
+					FileName:      fn.srcFile,
+					StartLine:     int(unit.stLine),
+					StartCol:      int(unit.stCol),
+					EndLine:       int(unit.enLine),
+					EndCol:        int(unit.enCol),
+					NumStatements: int(unit.numStmts),
+					HitCount:      int(count),
+				})
+			}
+		}
+	}
+	return blocks
+}
+
+// decodeMetaFile decodes a covmeta file: a header, the offset and length of
+// every package blob, a (unused) file-level string table and the blobs.
+func decodeMetaFile(metaPath string) (*coverMetaFile, error) {
+	content, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < metaFileHeaderSize || !bytes.Equal(content[:4], covMetaMagic) {
+		return nil, errors.New("invalid magic")
+	}
+
+	le := binary.LittleEndian
+	meta := &coverMetaFile{
+		counterMode: content[48],
+		perFunc:     content[49] == counterGranularityFunc,
+	}
+	copy(meta.hash[:], content[24:40])
+
+	numPackages := le.Uint64(content[16:24])
+	tablesEnd := metaFileHeaderSize + 16*numPackages
+	if numPackages > uint64(len(content)) || tablesEnd > uint64(len(content)) {
+		return nil, errors.New("truncated package tables")
+	}
+
+	for i := uint64(0); i < numPackages; i++ {
+		offset := le.Uint64(content[metaFileHeaderSize+8*i:])
+		length := le.Uint64(content[metaFileHeaderSize+8*(numPackages+i):])
+		if offset > uint64(len(content)) || length > uint64(len(content))-offset {
+			return nil, fmt.Errorf("package %d is out of bounds", i)
+		}
+		funcs, err := decodeMetaPackage(content[offset : offset+length])
+		if err != nil {
+			return nil, fmt.Errorf("package %d: %w", i, err)
+		}
+		meta.packages = append(meta.packages, funcs)
+	}
+	return meta, nil
+}
+
+// decodeMetaPackage decodes the meta-data blob of a single package: a header,
+// the offset of every function, a string table and the function records.
+func decodeMetaPackage(blob []byte) ([]coverFunc, error) {
+	if len(blob) < metaSymbolHeaderSize {
+		return nil, errors.New("truncated header")
+	}
+	numFuncs := binary.LittleEndian.Uint32(blob[40:44])
+	if uint64(numFuncs)*4 > uint64(len(blob)) {
+		return nil, errors.New("truncated function table")
+	}
+
+	reader := &covReader{data: blob, pos: metaSymbolHeaderSize + 4*int(numFuncs)}
+	stringTable := reader.stringTable()
+
+	funcs := make([]coverFunc, 0, numFuncs)
+	for i := 0; i < int(numFuncs); i++ {
+		reader.pos = metaSymbolHeaderSize + 4*i
+		reader.pos = int(reader.uint32(binary.LittleEndian))
+
+		numUnits := reader.uleb()
+		_ = reader.uleb() // function name
+		fileIdx := reader.uleb()
+		if reader.err != nil || fileIdx >= uint64(len(stringTable)) || numUnits > uint64(len(blob)) {
+			return nil, fmt.Errorf("malformed function %d", i)
+		}
+
+		fn := coverFunc{srcFile: stringTable[fileIdx], units: make([]coverUnit, 0, numUnits)}
+		for u := uint64(0); u < numUnits; u++ {
+			fn.units = append(fn.units, coverUnit{
+				stLine:   uint32(reader.uleb()),
+				stCol:    uint32(reader.uleb()),
+				enLine:   uint32(reader.uleb()),
+				enCol:    uint32(reader.uleb()),
+				numStmts: uint32(reader.uleb()),
+			})
+		}
+		_ = reader.uleb() // function literal flag
+		if reader.err != nil {
+			return nil, fmt.Errorf("malformed function %d: %w", i, reader.err)
+		}
+		funcs = append(funcs, fn)
+	}
+	return funcs, nil
+}
+
+// decodeCounterFile merges the counters of every segment of a covcounters file
+// into counters. Set-mode counters are combined with a logical OR; the other
+// modes are summed, saturating at the uint32 limit like "go tool covdata".
+func decodeCounterFile(counterPath string, counterMode uint8, counters map[funcKey][]uint32) error {
+	content, err := os.ReadFile(counterPath)
+	if err != nil {
+		return err
+	}
+	if len(content) < counterFileHeaderSize+counterFileFooterSize {
+		return errors.New("truncated file")
+	}
+	footer := content[len(content)-counterFileFooterSize:]
+	if !bytes.Equal(footer[:4], covCounterMagic) {
+		return errors.New("invalid footer magic")
+	}
+
+	flavor := content[24]
+	var order binary.ByteOrder = binary.LittleEndian
+	if content[25] != 0 {
+		order = binary.BigEndian
+	}
+	if flavor != counterFlavorRaw && flavor != counterFlavorULeb128 {
+		return fmt.Errorf("unknown counter flavor %d", flavor)
+	}
+
+	reader := &covReader{data: content, pos: counterFileHeaderSize}
+	readValue := func() uint32 {
+		if flavor == counterFlavorULeb128 {
+			return uint32(reader.uleb())
+		}
+		return reader.uint32(order)
+	}
+
+	numSegments := binary.LittleEndian.Uint32(footer[8:12])
+	for seg := uint32(0); seg < numSegments; seg++ {
+		numFuncs := reader.uint64(binary.LittleEndian)
+		strTabLen := reader.uint32(binary.LittleEndian)
+		argsLen := reader.uint32(binary.LittleEndian)
+		reader.skip(int(strTabLen) + int(argsLen))
+		reader.skip((4 - reader.pos%4) % 4)
+
+		for f := uint64(0); f < numFuncs && reader.err == nil; f++ {
+			numCounters := readValue()
+			key := funcKey{pkgIdx: readValue(), funcIdx: readValue()}
+			if uint64(numCounters) > uint64(len(content)) {
+				return fmt.Errorf("malformed function record in segment %d", seg)
+			}
+
+			existing := counters[key]
+			if len(existing) < int(numCounters) {
+				existing = append(existing, make([]uint32, int(numCounters)-len(existing))...)
+			}
+			for c := 0; c < int(numCounters); c++ {
+				existing[c] = mergeCounter(existing[c], readValue(), counterMode)
+			}
+			counters[key] = existing
+		}
+		reader.skip(counterFileFooterSize)
+
+		if reader.err != nil {
+			return fmt.Errorf("segment %d: %w", seg, reader.err)
+		}
+	}
+	return nil
+}
+
+func mergeCounter(existing, value uint32, counterMode uint8) uint32 {
+	if counterMode == counterModeSet {
+		if existing != 0 || value != 0 {
+			return 1
+		}
+		return 0
+	}
+	if uint64(existing)+uint64(value) > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return existing + value
+}
+
+// covReader is a bounds-checked cursor over a coverage data file. The first
+// out-of-bounds read is recorded in err and all later reads return zero.
+type covReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *covReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos < 0 || r.pos+n > len(r.data) {
+		r.err = errors.New("unexpected end of data")
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *covReader) skip(n int) {
+	r.take(n)
+}
+
+func (r *covReader) uint32(order binary.ByteOrder) uint32 {
+	if b := r.take(4); b != nil {
+		return order.Uint32(b)
+	}
+	return 0
+}
+
+func (r *covReader) uint64(order binary.ByteOrder) uint64 {
+	if b := r.take(8); b != nil {
+		return order.Uint64(b)
+	}
+	return 0
+}
+
+func (r *covReader) uleb() uint64 {
+	var value uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b := r.take(1)
+		if b == nil {
+			return 0
+		}
+		value |= uint64(b[0]&0x7f) << shift
+		if b[0]&0x80 == 0 {
+			return value
+		}
+	}
+	r.err = errors.New("malformed uleb128 value")
+	return 0
+}
+
+// stringTable reads a table of uleb128-prefixed strings.
+func (r *covReader) stringTable() []string {
+	count := r.uleb()
+	if count > uint64(len(r.data)) {
+		r.err = errors.New("malformed string table")
+		return nil
+	}
+	table := make([]string, 0, count)
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		length := r.uleb()
+		table = append(table, string(r.take(int(length))))
+	}
+	return table
+}
@@ -1,5 +1,11 @@
 package parser_gocover
 
+// GoCoverProfile is the parsed content of a Go coverage profile or GOCOVERDIR.
+type GoCoverProfile struct {
+	Mode   string // "set", "count" or "atomic".
+	Blocks []GoCoverProfileBlock
+}
+
 // GoCoverProfileBlock represents a single parsed line from a Go coverage profile.
 // The format is: path/to/file.go:startLine.startCol,endLine.endCol numStatements hitCount
 type GoCoverProfileBlock struct {
@@ -10,8 +10,8 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
 )
 
 var (
@@ -21,13 +21,13 @@ var (
 
 // GoCoverParser implements the parsers.IParser interface for Go coverage reports.
 type GoCoverParser struct {
-	fileReader filereader.Reader
+	resolver *utils.SourceResolver
 }
 
 // NewGoCoverParser creates a new parser instance.
-func NewGoCoverParser(fileReader filereader.Reader) parsers.IParser {
+func NewGoCoverParser(resolver *utils.SourceResolver) parsers.IParser {
 	return &GoCoverParser{
-		fileReader: fileReader,
+		resolver: resolver,
 	}
 }
 
@@ -37,7 +37,13 @@ func (p *GoCoverParser) Name() string {
 }
 
 // SupportsFile performs a fast check to see if this parser can handle the file.
+// Besides textual profiles, it accepts GOCOVERDIR directories written by
+// binaries built with "go build -cover".
 func (p *GoCoverParser) SupportsFile(filePath string) bool {
+	if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+		return isGoCoverDir(filePath)
+	}
+
 	f, err := os.Open(filePath)
 	if err != nil {
 		return false
@@ -58,39 +64,51 @@ func (p *GoCoverParser) SupportsFile(filePath string) bool {
 func (p *GoCoverParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
 	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
 
-	profileBlocks, err := p.loadAndParseGoCoverFile(filePath)
+	var profile *GoCoverProfile
+	var err error
+	if info, statErr := os.Stat(filePath); statErr == nil && info.IsDir() {
+		profile, err = loadGoCoverDir(filePath)
+	} else {
+		profile, err = p.loadAndParseGoCoverFile(filePath)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to load/parse Go coverage file from %s: %w", filePath, err)
 	}
 
-	orchestrator := newProcessingOrchestrator(p.fileReader, config, logger)
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
 
 	// The orchestrator now returns a simple slice of FileCoverage objects.
-	fileCoverage, unresolvedFiles := orchestrator.processBlocks(profileBlocks)
+	fileCoverage, unresolvedFiles := orchestrator.processBlocks(profile.Blocks)
 
 	return &parsers.ParserResult{
 		FileCoverage:          fileCoverage,
 		ParserName:            p.Name(),
 		UnresolvedSourceFiles: unresolvedFiles,
+		CoverageMode:          profile.Mode,
 	}, nil
 }
 
-// loadAndParseGoCoverFile reads the specified file line-by-line and parses each
-// valid coverage data line into a GoCoverProfileBlock.
-func (p *GoCoverParser) loadAndParseGoCoverFile(path string) ([]GoCoverProfileBlock, error) {
+// loadAndParseGoCoverFile reads the specified file line-by-line. The leading
+// "mode:" line gives the counting mode and every valid coverage data line is
+// parsed into a GoCoverProfileBlock.
+func (p *GoCoverParser) loadAndParseGoCoverFile(path string) (*GoCoverProfile, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("open file: %w", err)
 	}
 	defer file.Close()
 
-	var blocks []GoCoverProfileBlock
+	profile := &GoCoverProfile{}
 	scanner := bufio.NewScanner(file)
 
-	// Skip the first line ("mode: ...")
 	if !scanner.Scan() {
 		return nil, fmt.Errorf("file is empty or could not be read")
 	}
+	mode, ok := strings.CutPrefix(scanner.Text(), "mode:")
+	if !ok {
+		return nil, fmt.Errorf("missing \"mode:\" line")
+	}
+	profile.Mode = strings.TrimSpace(mode)
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -104,7 +122,7 @@ func (p *GoCoverParser) loadAndParseGoCoverFile(path string) ([]GoCoverProfileBl
 			numStatements, _ := strconv.Atoi(match[6])
 			hitCount, _ := strconv.Atoi(match[7])
 
-			blocks = append(blocks, GoCoverProfileBlock{
+			profile.Blocks = append(profile.Blocks, GoCoverProfileBlock{
 				FileName:      match[1],
 				StartLine:     startLine,
 				StartCol:      startCol,
@@ -120,5 +138,35 @@ func (p *GoCoverParser) loadAndParseGoCoverFile(path string) ([]GoCoverProfileBl
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
 
-	return blocks, nil
+	profile.Blocks = mergeDuplicateBlocks(profile.Blocks, profile.Mode)
+	return profile, nil
+}
+
+// mergeDuplicateBlocks combines blocks that appear more than once, as happens
+// when profiles are concatenated or a package is covered by several test
+// binaries with -coverpkg. Like "go tool cover", set-mode hits are combined
+// with a logical OR and the other modes are summed.
+func mergeDuplicateBlocks(blocks []GoCoverProfileBlock, mode string) []GoCoverProfileBlock {
+	type blockKey struct {
+		fileName                             string
+		startLine, startCol, endLine, endCol int
+	}
+
+	indexByKey := make(map[blockKey]int, len(blocks))
+	merged := make([]GoCoverProfileBlock, 0, len(blocks))
+	for _, block := range blocks {
+		key := blockKey{block.FileName, block.StartLine, block.StartCol, block.EndLine, block.EndCol}
+		index, seen := indexByKey[key]
+		if !seen {
+			indexByKey[key] = len(merged)
+			merged = append(merged, block)
+			continue
+		}
+		if mode == "set" {
+			merged[index].HitCount = max(merged[index].HitCount, min(block.HitCount, 1))
+		} else {
+			merged[index].HitCount += block.HitCount
+		}
+	}
+	return merged
 }
@@ -1,13 +1,17 @@
 package parser_gocover_test
 
 import (
+	"encoding/base64"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/parsers/parser_gocover"
 	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/IgorBayerl/nanovision/pathmapping"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -39,6 +43,7 @@ github.com/user/project/calculator.go:6.24,7.18 1 0
 				require.NotNil(t, result)
 				assert.Empty(t, result.UnresolvedSourceFiles, "Source file should be resolved")
 				assert.Equal(t, "GoCover", result.ParserName)
+				assert.Equal(t, "set", result.CoverageMode)
 
 				require.Len(t, result.FileCoverage, 1, "Should produce coverage for one file")
 				fileCov := result.FileCoverage[0]
@@ -104,6 +109,68 @@ project/file2.go:2.1,2.12 1 0
 				assert.Equal(t, 0, coverageByPath["project/file2.go"].Lines[2].Hits)
 			},
 		},
+		{
+			name: "Column-precise blocks - Lines shared by blocks that disagree are partial",
+			reportContent: `mode: count
+project/sign.go:3.24,4.12 1 3
+project/sign.go:4.12,6.3 1 0
+project/sign.go:7.2,7.12 1 3
+project/sign.go:7.12,7.22 1 0
+project/sign.go:8.2,8.10 1 3
+project/sign.go:8.2,8.10 1 2
+`,
+			sourceFiles: map[string]string{
+				"/app/src/project/sign.go": "package project\n\nfunc Sign(x int) int {\n\tif x < 0 {\n\t\treturn -1\n\t}\n\tif x == 0 { return 0 }\n\treturn 1\n}\n",
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, "count", result.CoverageMode)
+				require.Len(t, result.FileCoverage, 1)
+				lines := result.FileCoverage[0].Lines
+
+				assert.NotContains(t, lines, 3, "The function body starts after the opening brace")
+				assert.Equal(t, model.LineMetrics{Hits: 3, CoveredStatements: 1, TotalStatements: 1}, lines[4],
+					"The block starting after 'if x < 0 {' does not touch line 4")
+				assert.Equal(t, model.LineMetrics{Hits: 0}, lines[5], "Lines inside a block carry no statements")
+				assert.Equal(t, model.LineMetrics{Hits: 3, CoveredStatements: 1, TotalStatements: 2}, lines[7],
+					"A one-line if with an uncovered body is partially covered")
+				assert.Equal(t, model.LineMetrics{Hits: 5, CoveredStatements: 1, TotalStatements: 1}, lines[8],
+					"Duplicate blocks are summed in count mode")
+			},
+		},
+		{
+			name: "Multi-line blocks - Statements are counted on the start and end lines only",
+			reportContent: `mode: set
+project/long.go:2.14,6.2 4 1
+`,
+			sourceFiles: map[string]string{
+				"/app/src/project/long.go": "// Dummy content",
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 1)
+				lines := result.FileCoverage[0].Lines
+
+				require.Len(t, lines, 5)
+				assert.Equal(t, model.LineMetrics{Hits: 1, CoveredStatements: 4, TotalStatements: 4}, lines[2])
+				for l := 3; l <= 5; l++ {
+					assert.Equal(t, model.LineMetrics{Hits: 1}, lines[l], "Line %d is inside the block", l)
+				}
+				assert.Equal(t, model.LineMetrics{Hits: 1, CoveredStatements: 4, TotalStatements: 4}, lines[6])
+			},
+		},
+		{
+			name:          "Missing mode line",
+			reportContent: "project/file1.go:1.1,1.10 1 5\n",
+			sourceFiles:   map[string]string{},
+			sourceDirs:    []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
 		{
 			name:          "Report is logically empty (only mode line)",
 			reportContent: `mode: set`,
@@ -133,7 +200,7 @@ project/file2.go:2.1,2.12 1 0
 			}
 
 			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
-			parser := parser_gocover.NewGoCoverParser(mockFS)
+			parser := parser_gocover.NewGoCoverParser(utils.NewSourceResolver(mockFS, nil))
 
 			// Act
 			result, err := parser.Parse(reportPath, mockConfig)
@@ -143,3 +210,146 @@ project/file2.go:2.1,2.12 1 0
 		})
 	}
 }
+
+func TestGoCoverParser_Parse_PathMappings(t *testing.T) {
+	tmpDir := t.TempDir()
+	reportPath := filepath.Join(tmpDir, "coverage.out")
+	report := "mode: count\n" +
+		"/build/lib/sign.go:3.24,4.12 1 3\n" +
+		"/build/lib/sign.go:4.12,6.3 1 0\n"
+	require.NoError(t, os.WriteFile(reportPath, []byte(report), 0644))
+
+	mockFS := testutil.NewMockFilesystem("unix")
+	mockFS.AddFile("/app/src/project/sign.go", "package project\n\nfunc Sign(x int) int {\n\tif x < 0 {\n\t\treturn -1\n\t}\n\treturn 1\n}\n")
+	mockFS.AddFile("/app/src/other/sign.go", "// Same name, different package")
+	// Without the mapping, "sign.go" would be ambiguous.
+	mapper, err := pathmapping.New([]pathmapping.Rule{{From: "/build/lib", To: "project"}})
+	require.NoError(t, err)
+	config := testutil.NewTestConfig([]string{"/app/src"})
+	config.(*testutil.MockParserConfig).Mapper = mapper
+
+	result, err := parser_gocover.NewGoCoverParser(utils.NewSourceResolver(mockFS, nil)).Parse(reportPath, config)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.UnresolvedSourceFiles, "The mapped path should resolve")
+	require.Len(t, result.FileCoverage, 1)
+	assert.Equal(t, "project/sign.go", result.FileCoverage[0].Path, "The mapped path should be reported")
+	assert.Equal(t, model.LineMetrics{Hits: 3, CoveredStatements: 1, TotalStatements: 1}, result.FileCoverage[0].Lines[4],
+		"The source of the mapped path should be read, so the inner block does not touch line 4")
+}
+
+// GOCOVERDIR content written by two runs of a "go build -cover -covermode=count"
+// binary whose textual equivalent ("go tool covdata textfmt") is:
+//
+//	example.com/app/main.go:11.2,11.36 1 2
+//	example.com/app/main.go:12.3,13.1 1 4
+//	example.com/app/calc/calc.go:4.2,4.11 1 4
+//	example.com/app/calc/calc.go:5.3,6.1 1 0
+//	example.com/app/calc/calc.go:7.2,7.12 1 4
+//	example.com/app/calc/calc.go:7.14,7.24 1 2
+//	example.com/app/calc/calc.go:8.2,8.10 1 2
+//	example.com/app/calc/calc.go:12.2,13.1 1 0
+var goCoverDirFiles = map[string]string{
+	"covmeta.5b88991f083dad4c11fe90bafe37f8d8":                              "AGN2bQEAAAB2AQAAAAAAAAIAAAAAAAAAW4iZHwg9rUwR/pC6/jf42FgAAAACAAAAAgEAAAAAAABaAAAAAAAAAAkBAAAAAAAArwAAAAAAAABtAAAAAAAAAAEArwAAAAIAAAABAAAAAwAAADYBFDeDT0DLLJ+lEKi5Gb0AAAAABwAAAAIAAACJAAAApgAAAAcAFGV4YW1wbGUuY29tL2FwcC9jYWxjBGNhbGMPZXhhbXBsZS5jb20vYXBwBFNpZ24cZXhhbXBsZS5jb20vYXBwL2NhbGMvY2FsYy5nbwZVbnVzZWQFBAUEAgQLAQcCBwwBCAIICgEFAwYBAQcOBxgBAAEGBQwCDQEBAG0AAAACAAAAAQAAAAEAAAAd+jLzo/vfaGnxmYGmDk6rAAAAAAQAAAABAAAAXwAAAAQAD2V4YW1wbGUuY29tL2FwcARtYWluF2V4YW1wbGUuY29tL2FwcC9tYWluLmdvAgIDCwILJAEMAw0BAQA=",
+	"covcounters.5b88991f083dad4c11fe90bafe37f8d8.9763.1792181175080118075": "AGN3bQEAAABbiJkfCD2tTBH+kLr+N/jYAgAAAAAAAAACAAAAAAAAAD0AAAAPAAAADQAER09PUwVsaW51eAZHT0FSQ0gFYW1kNjQEYXJnYwEzBWFyZ3YwBS4vYXBwBWFyZ3YxAWEFYXJndjIBYgYDBAECBQYHCAkKCwwAAAIBAAEDBQAAAwMCAAEAY3dtAAAAAAEAAAAAAAAA",
+	"covcounters.5b88991f083dad4c11fe90bafe37f8d8.9767.1792181175082923077": "AGN3bQEAAABbiJkfCD2tTBH+kLr+N/jYAgAAAAAAAAACAAAAAAAAAC0AAAALAAAACQAEYXJnYwExBWFyZ3YwBS4vYXBwBEdPT1MFbGludXgGR09BUkNIBWFtZDY0BAcIBQYBAgMEAAACAQABAQUAAAEBAAABAGN3bQAAAAABAAAAAAAAAA==",
+}
+
+func writeGoCoverDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	coverDir := t.TempDir()
+	for name, encoded := range files {
+		content, err := base64.StdEncoding.DecodeString(encoded)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(coverDir, name), content, 0644))
+	}
+	return coverDir
+}
+
+func TestGoCoverParser_ParseCoverDir(t *testing.T) {
+	coverDir := writeGoCoverDir(t, goCoverDirFiles)
+
+	mockFS := testutil.NewMockFilesystem("unix")
+	mockFS.AddFile("/app/src/example.com/app/calc/calc.go", "file content here")
+	parser := parser_gocover.NewGoCoverParser(utils.NewSourceResolver(mockFS, nil))
+
+	require.True(t, parser.SupportsFile(coverDir), "A GOCOVERDIR should be recognized")
+
+	result, err := parser.Parse(coverDir, testutil.NewTestConfig([]string{"/app/src"}))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, "count", result.CoverageMode)
+	assert.Equal(t, []string{"example.com/app/main.go"}, result.UnresolvedSourceFiles)
+
+	linesByFile := make(map[string]map[int]int)
+	for _, fileCov := range result.FileCoverage {
+		linesByFile[fileCov.Path] = make(map[int]int)
+		for lineNumber, metric := range fileCov.Lines {
+			linesByFile[fileCov.Path][lineNumber] = metric.Hits
+		}
+	}
+
+	// Counters of both runs are summed, as in "count" mode.
+	assert.Equal(t, map[int]int{4: 4, 5: 0, 6: 0, 7: 4, 8: 2, 12: 0, 13: 0}, linesByFile["example.com/app/calc/calc.go"])
+	assert.Equal(t, map[int]int{11: 2, 12: 4, 13: 4}, linesByFile["example.com/app/main.go"])
+}
+
+// GOCOVERDIR content written by two "go build -cover -coverpkg=./..." binaries
+// that both cover the calc package, one calling calc.Sign(-1) and the other
+// calc.Sign(1). Its textual equivalent ("go tool covdata textfmt") is:
+//
+//	example.com/app/calc/calc.go:4.2,4.11 1 2
+//	example.com/app/calc/calc.go:5.3,6.1 1 1
+//	example.com/app/calc/calc.go:7.2,7.10 1 1
+//	example.com/app/cmd/neg/main.go:5.15,5.30 1 1
+//	example.com/app/cmd/pos/main.go:5.15,5.29 1 1
+var goCoverDirTwoBinariesFiles = map[string]string{
+	"covcounters.46c0b10b7ec68541e7457e2409341157.14459.1792185378358998489": "AGN3bQEAAABGwLELfsaFQedFfiQJNBFXAgAAAAAAAAACAAAAAAAAAC0AAAALAAAACQAFYXJndjAFLi9wb3MER09PUwVsaW51eAZHT0FSQ0gFYW1kNjQEYXJnYwExBAUGAwQHCAECAAABAQABAwAAAQEAAGN3bQAAAAABAAAAAAAAAA==",
+	"covcounters.b0f5a204e26192e7874978d1ede79846.14456.1792185378356721299": "AGN3bQEAAACw9aIE4mGS54dJeNHt55hGAgAAAAAAAAACAAAAAAAAAC0AAAALAAAACQAEYXJnYwExBWFyZ3YwBS4vbmVnBEdPT1MFbGludXgGR09BUkNIBWFtZDY0BAcIBQYBAgMEAAABAQABAwAAAQABAGN3bQAAAAABAAAAAAAAAA==",
+	"covmeta.46c0b10b7ec68541e7457e2409341157":                               "AGN2bQEAAABzAQAAAAAAAAIAAAAAAAAARsCxC37GhUHnRX4kCTQRV1gAAAACAAAAAgEAAAAAAABaAAAAAAAAAOsAAAAAAAAAkQAAAAAAAACIAAAAAAAAAAEAkQAAAAIAAAABAAAAAwAAAJQ3fOy0V2xVt1ZWBmKvRb8AAAAABgAAAAEAAAB+AAAABgAUZXhhbXBsZS5jb20vYXBwL2NhbGMEY2FsYw9leGFtcGxlLmNvbS9hcHAEU2lnbhxleGFtcGxlLmNvbS9hcHAvY2FsYy9jYWxjLmdvAwQFBAIECwEHAgcKAQUDBgEBAIgAAAACAAAAAQAAAAMAAAB1/SRWFKFx5HI2p2tLJJSFAAAAAAUAAAABAAAAfwAAAAUAF2V4YW1wbGUuY29tL2FwcC9jbWQvcG9zBG1haW4PZXhhbXBsZS5jb20vYXBwH2V4YW1wbGUuY29tL2FwcC9jbWQvcG9zL21haW4uZ28BAgQFDwUdAQA=",
+	"covmeta.b0f5a204e26192e7874978d1ede79846":                               "AGN2bQEAAABzAQAAAAAAAAIAAAAAAAAAsPWiBOJhkueHSXjR7eeYRlgAAAACAAAAAgEAAAAAAABaAAAAAAAAAOsAAAAAAAAAkQAAAAAAAACIAAAAAAAAAAEAkQAAAAIAAAABAAAAAwAAAJQ3fOy0V2xVt1ZWBmKvRb8AAAAABgAAAAEAAAB+AAAABgAUZXhhbXBsZS5jb20vYXBwL2NhbGMEY2FsYw9leGFtcGxlLmNvbS9hcHAEU2lnbhxleGFtcGxlLmNvbS9hcHAvY2FsYy9jYWxjLmdvAwQFBAIECwEHAgcKAQUDBgEBAIgAAAACAAAAAQAAAAMAAACA5SGg+d3IOkerdKo1ttBGAAAAAAUAAAABAAAAfwAAAAUAF2V4YW1wbGUuY29tL2FwcC9jbWQvbmVnBG1haW4PZXhhbXBsZS5jb20vYXBwH2V4YW1wbGUuY29tL2FwcC9jbWQvbmVnL21haW4uZ28BAgQFDwUeAQA=",
+}
+
+func TestGoCoverParser_ParseCoverDir_SeveralBinaries(t *testing.T) {
+	coverDir := writeGoCoverDir(t, goCoverDirTwoBinariesFiles)
+
+	mockFS := testutil.NewMockFilesystem("unix")
+	mockFS.AddFile("/app/src/example.com/app/calc/calc.go", "file content here")
+	parser := parser_gocover.NewGoCoverParser(utils.NewSourceResolver(mockFS, nil))
+
+	result, err := parser.Parse(coverDir, testutil.NewTestConfig([]string{"/app/src"}))
+	require.NoError(t, err)
+
+	var calc map[int]model.LineMetrics
+	for _, fileCov := range result.FileCoverage {
+		if fileCov.Path == "example.com/app/calc/calc.go" {
+			calc = fileCov.Lines
+		}
+	}
+	require.NotNil(t, calc)
+
+	// The blocks of both binaries are merged, as by "go tool covdata textfmt",
+	// so no line is partially covered.
+	assert.Equal(t, map[int]model.LineMetrics{
+		4: {Hits: 2, CoveredStatements: 1, TotalStatements: 1},
+		5: {Hits: 1, CoveredStatements: 1, TotalStatements: 1},
+		6: {Hits: 1, CoveredStatements: 1, TotalStatements: 1},
+		7: {Hits: 1, CoveredStatements: 1, TotalStatements: 1},
+	}, calc)
+}
+
+func TestGoCoverParser_SupportsFile(t *testing.T) {
+	parser := parser_gocover.NewGoCoverParser(utils.NewSourceResolver(testutil.NewMockFilesystem("unix"), nil))
+
+	emptyDir := t.TempDir()
+	countersOnlyDir := writeGoCoverDir(t, map[string]string{
+		"covcounters.5b88991f083dad4c11fe90bafe37f8d8.9763.1792181175080118075": goCoverDirFiles["covcounters.5b88991f083dad4c11fe90bafe37f8d8.9763.1792181175080118075"],
+	})
+
+	profile := filepath.Join(t.TempDir(), "coverage.out")
+	require.NoError(t, os.WriteFile(profile, []byte("mode: set\n"), 0644))
+
+	assert.True(t, parser.SupportsFile(profile))
+	assert.False(t, parser.SupportsFile(emptyDir))
+	assert.False(t, parser.SupportsFile(countersOnlyDir), "Counter files are useless without their meta-data file")
+}
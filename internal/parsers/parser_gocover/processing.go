@@ -3,8 +3,8 @@ package parser_gocover
 import (
 	"log/slog"
 	"path/filepath"
+	"strings"
 
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
 	"github.com/IgorBayerl/nanovision/internal/utils"
@@ -13,16 +13,16 @@ import (
 // processingOrchestrator now holds state for converting raw blocks into a flat
 // list of per-file coverage data. It no longer performs aggregation.
 type processingOrchestrator struct {
-	fileReader filereader.Reader
-	config     parsers.ParserConfig
-	logger     *slog.Logger
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
 }
 
-func newProcessingOrchestrator(fileReader filereader.Reader, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
 	return &processingOrchestrator{
-		fileReader: fileReader,
-		config:     config,
-		logger:     logger,
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
 	}
 }
 
@@ -42,15 +42,18 @@ func (o *processingOrchestrator) processBlocks(blocks []GoCoverProfileBlock) ([]
 		sourceDir = o.config.SourceDirectories()[0]
 	}
 
-	for filePath, fileBlocks := range blocksByFile {
-		// Pass the logger from the orchestrator into the find utility
-		_, err := utils.FindFileInSourceDirs(filePath, []string{sourceDir}, o.fileReader, o.logger)
+	for reportPath, fileBlocks := range blocksByFile {
+		filePath := parsers.MapReportPath(o.config, reportPath)
+		var sourceLines []string
+		resolution, err := o.resolver.Resolve(filePath, []string{sourceDir})
 		if err != nil {
 			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
 			allUnresolvedFiles = append(allUnresolvedFiles, filePath)
+		} else if sourceLines, err = o.resolver.ReadFile(resolution.Path); err != nil {
+			o.logger.Warn("Could not read source file, block columns will be ignored.", "file", resolution.Path, "error", err)
 		}
 
-		fileCoverage := o.processFile(filePath, fileBlocks)
+		fileCoverage := o.processFile(filePath, fileBlocks, sourceLines)
 		allFileCoverage = append(allFileCoverage, fileCoverage)
 	}
 
@@ -73,24 +76,39 @@ func (o *processingOrchestrator) groupBlocksByFile(blocks []GoCoverProfileBlock)
 
 // processFile converts all coverage blocks for a single file into a single
 // FileCoverage struct, which contains a map of line numbers to their metrics.
-func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverProfileBlock) parsers.FileCoverage {
+// When the source is available, block columns decide which lines a block
+// really touches; otherwise every line from StartLine to EndLine is used.
+func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverProfileBlock, sourceLines []string) parsers.FileCoverage {
 	lineMetrics := make(map[int]model.LineMetrics)
 
 	for _, block := range blocks {
 		// A single block can span multiple lines. We apply its hit count to
 		// every line within its range.
 		for l := block.StartLine; l <= block.EndLine; l++ {
+			if !blockTouchesLine(block, l, sourceLines) {
+				continue
+			}
+
 			// If multiple blocks cover the same line, the Go tool's convention is
 			// that the hit count of one of them applies. We take the highest hit count
 			// as the most representative value for that line's execution status.
-			if existing, ok := lineMetrics[l]; !ok || block.HitCount > existing.Hits {
-				lineMetrics[l] = model.LineMetrics{
-					Hits: block.HitCount,
-					// Go coverage profiles do not support branch coverage.
-					TotalBranches:   0,
-					CoveredBranches: 0,
+			metric, ok := lineMetrics[l]
+			if !ok || block.HitCount > metric.Hits {
+				metric.Hits = block.HitCount
+			}
+
+			// Go coverage profiles do not support branch coverage. Instead, the
+			// statements of the blocks that start or end on a line are kept, so
+			// that lines where blocks disagree can be reported as partially
+			// covered. Blocks do not overlap, so the lines in between belong to
+			// a single block and carry no statements.
+			if l == block.StartLine || l == block.EndLine {
+				metric.TotalStatements += block.NumStatements
+				if block.HitCount > 0 {
+					metric.CoveredStatements += block.NumStatements
 				}
 			}
+			lineMetrics[l] = metric
 		}
 	}
 
@@ -99,3 +117,28 @@ func (o *processingOrchestrator) processFile(filePath string, blocks []GoCoverPr
 		Lines: lineMetrics,
 	}
 }
+
+// blockTouchesLine reports whether a block covers any code of the given line.
+// Blocks usually start right after an opening brace, e.g. "if x {", and the
+// previous block ends on that same line. Without looking at the columns, the
+// start line of the inner block would be attributed to both blocks.
+// Columns are 1-based byte offsets, and the end column is exclusive.
+func blockTouchesLine(block GoCoverProfileBlock, lineNumber int, sourceLines []string) bool {
+	if lineNumber <= 0 || lineNumber > len(sourceLines) {
+		return lineNumber > 0 // Unknown line content; trust the line range.
+	}
+	line := sourceLines[lineNumber-1]
+	firstCol := len(line) - len(strings.TrimLeft(line, " \t")) + 1
+	lastCol := len(strings.TrimRight(line, " \t\r"))
+	if lastCol < firstCol {
+		return false // Blank line inside a block.
+	}
+
+	if lineNumber == block.StartLine && block.StartCol > lastCol {
+		return false
+	}
+	if lineNumber == block.EndLine && block.EndCol <= firstCol {
+		return false
+	}
+	return true
+}
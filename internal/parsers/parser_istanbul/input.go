@@ -0,0 +1,45 @@
+package parser_istanbul
+
+// FileCoverageJSON is the coverage of a single file, as serialized by
+// istanbul-lib-coverage in "coverage-final.json". The report itself is a map
+// of file path to FileCoverageJSON. Counters are keyed by the same ids as the
+// corresponding location maps.
+type FileCoverageJSON struct {
+	Path         string                  `json:"path"`
+	StatementMap map[string]RangeJSON    `json:"statementMap"`
+	FnMap        map[string]FunctionJSON `json:"fnMap"`
+	BranchMap    map[string]BranchJSON   `json:"branchMap"`
+	S            map[string]int          `json:"s"`
+	F            map[string]int          `json:"f"`
+	B            map[string][]int        `json:"b"`
+}
+
+// RangeJSON is a source range. Istanbul uses 1-based lines and 0-based
+// columns; the column may be null for ranges that end at the end of a line.
+type RangeJSON struct {
+	Start PositionJSON `json:"start"`
+	End   PositionJSON `json:"end"`
+}
+
+// PositionJSON is a single position of a RangeJSON.
+type PositionJSON struct {
+	Line   int  `json:"line"`
+	Column *int `json:"column"`
+}
+
+// FunctionJSON is an entry of "fnMap".
+type FunctionJSON struct {
+	Name string    `json:"name"`
+	Decl RangeJSON `json:"decl"`
+	Loc  RangeJSON `json:"loc"`
+	Line int       `json:"line"`
+}
+
+// BranchJSON is an entry of "branchMap". Each location is one arm of the
+// branch, and its hit count is at the same index in the "b" counters.
+type BranchJSON struct {
+	Type      string      `json:"type"`
+	Line      int         `json:"line"`
+	Loc       RangeJSON   `json:"loc"`
+	Locations []RangeJSON `json:"locations"`
+}
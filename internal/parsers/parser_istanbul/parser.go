@@ -0,0 +1,132 @@
+package parser_istanbul
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// IstanbulParser implements the parsers.IParser interface for the
+// "coverage-final.json" reports written by Istanbul, nyc, Jest and Vitest.
+type IstanbulParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewIstanbulParser creates a new parser instance.
+func NewIstanbulParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &IstanbulParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *IstanbulParser) Name() string {
+	return "Istanbul"
+}
+
+// SupportsFile performs a fast check to see if this parser can handle the given file.
+// It verifies the file has a ".json" extension, that its root is an object and
+// that the first entry of that object carries a "statementMap".
+func (p *IstanbulParser) SupportsFile(filePath string) bool {
+	if !strings.HasSuffix(strings.ToLower(filePath), ".json") {
+		return false
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return false
+	}
+	if token, err := decoder.Token(); err != nil {
+		return false
+	} else if _, isKey := token.(string); !isKey {
+		return false // Empty object; nothing identifies the format.
+	}
+
+	// Only the first entry is decoded, which keeps the check cheap on large reports.
+	var firstEntry json.RawMessage
+	if err := decoder.Decode(&firstEntry); err != nil {
+		return false
+	}
+	fileCov, ok := decodeFileCoverage(firstEntry)
+	return ok && fileCov.StatementMap != nil
+}
+
+// Parse unmarshals the Istanbul JSON report and delegates the conversion to a
+// flat list of FileCoverage objects to the processingOrchestrator.
+func (p *IstanbulParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	files, err := p.loadAndUnmarshalIstanbulJSON(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/unmarshal Istanbul JSON from %s: %w", filePath, err)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processFiles(files)
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
+
+// ------ Helper Functions ------
+
+// loadAndUnmarshalIstanbulJSON reads the report and returns its entries keyed
+// by file path. Entries without an explicit "path" use their key instead.
+func (p *IstanbulParser) loadAndUnmarshalIstanbulJSON(path string) (map[string]FileCoverageJSON, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var rawEntries map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &rawEntries); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+
+	files := make(map[string]FileCoverageJSON, len(rawEntries))
+	for key, rawEntry := range rawEntries {
+		fileCov, ok := decodeFileCoverage(rawEntry)
+		if !ok {
+			return nil, fmt.Errorf("unmarshal json: entry %q is not a file coverage object", key)
+		}
+		if fileCov.Path == "" {
+			fileCov.Path = key
+		}
+		files[fileCov.Path] = fileCov
+	}
+	return files, nil
+}
+
+// decodeFileCoverage decodes a single report entry. Reports written from a
+// serialized istanbul-lib-coverage FileCoverage wrap the data in a "data" key.
+func decodeFileCoverage(raw json.RawMessage) (FileCoverageJSON, bool) {
+	var fileCov FileCoverageJSON
+	if err := json.Unmarshal(raw, &fileCov); err != nil {
+		return FileCoverageJSON{}, false
+	}
+	if fileCov.StatementMap != nil {
+		return fileCov, true
+	}
+
+	var wrapped struct {
+		Data FileCoverageJSON `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err != nil || wrapped.Data.StatementMap == nil {
+		return fileCov, true
+	}
+	return wrapped.Data, true
+}
@@ -0,0 +1,171 @@
+package parser_istanbul_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_istanbul"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIstanbulParser_Parse(t *testing.T) {
+	const reportFileName = "coverage-final.json"
+	const sourceDir = "/app"
+	const sourceFilePath = "/app/src/math.js"
+
+	testCases := []struct {
+		name          string
+		reportContent string
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name: "Golden Path - Statements, branches and functions",
+			reportContent: `{
+  "/app/src/math.js": {
+    "path": "/app/src/math.js",
+    "statementMap": {
+      "0": {"start": {"line": 2, "column": 2}, "end": {"line": 4, "column": 3}},
+      "1": {"start": {"line": 3, "column": 4}, "end": {"line": 3, "column": 13}},
+      "2": {"start": {"line": 5, "column": 2}, "end": {"line": 5, "column": 12}},
+      "3": {"start": {"line": 8, "column": 0}, "end": {"line": 8, "column": null}}
+    },
+    "fnMap": {
+      "0": {
+        "name": "abs",
+        "decl": {"start": {"line": 1, "column": 9}, "end": {"line": 1, "column": 12}},
+        "loc": {"start": {"line": 1, "column": 15}, "end": {"line": 6, "column": 1}},
+        "line": 1
+      }
+    },
+    "branchMap": {
+      "0": {
+        "loc": {"start": {"line": 2, "column": 2}, "end": {"line": 4, "column": 3}},
+        "type": "if",
+        "locations": [
+          {"start": {"line": 2, "column": 2}, "end": {"line": 4, "column": 3}},
+          {"start": {}, "end": {}}
+        ],
+        "line": 2
+      }
+    },
+    "s": {"0": 4, "1": 0, "2": 4, "3": 1},
+    "f": {"0": 4},
+    "b": {"0": [0, 4]}
+  }
+}`,
+			sourceFiles: map[string]string{
+				sourceFilePath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "Istanbul", result.ParserName)
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, sourceFilePath, fileCov.Path)
+				require.Len(t, fileCov.Lines, 5, "Lines 2-5 and 8 should be coverable")
+
+				assert.Equal(t, 4, fileCov.Lines[2].Hits)
+				assert.Equal(t, 4, fileCov.Lines[3].Hits, "The highest hit count of overlapping statements should win")
+				assert.Equal(t, 4, fileCov.Lines[4].Hits)
+				assert.Equal(t, 1, fileCov.Lines[8].Hits)
+
+				assert.Equal(t, 2, fileCov.Lines[2].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[2].CoveredBranches)
+
+				require.Len(t, fileCov.Methods, 1)
+				assert.Equal(t, "abs", fileCov.Methods[0].Name)
+				assert.Equal(t, 1, fileCov.Methods[0].StartLine)
+				assert.Equal(t, 6, fileCov.Methods[0].EndLine)
+			},
+		},
+		{
+			name: "Wrapped entries without path use their key",
+			reportContent: `{
+  "src/util.js": {
+    "data": {
+      "statementMap": {"0": {"start": {"line": 1, "column": 0}, "end": {"line": 1, "column": 10}}},
+      "fnMap": {},
+      "branchMap": {},
+      "s": {"0": 0},
+      "f": {},
+      "b": {}
+    }
+  }
+}`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 1)
+				assert.Equal(t, "src/util.js", result.FileCoverage[0].Path)
+				assert.Equal(t, 0, result.FileCoverage[0].Lines[1].Hits)
+
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, "src/util.js", result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name:          "Malformed JSON",
+			reportContent: `{"src/util.js": {"statementMap": `,
+			sourceFiles:   map[string]string{},
+			sourceDirs:    []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, reportFileName)
+			err := os.WriteFile(reportPath, []byte(tc.reportContent), 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_istanbul.NewIstanbulParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
+
+func TestIstanbulParser_SupportsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	parser := parser_istanbul.NewIstanbulParser(utils.NewSourceResolver(testutil.NewMockFilesystem("unix"), nil))
+
+	istanbulFile := filepath.Join(tmpDir, "coverage-final.json")
+	require.NoError(t, os.WriteFile(istanbulFile, []byte(`{"a.js": {"path": "a.js", "statementMap": {}, "s": {}}}`), 0644))
+
+	coveragePyFile := filepath.Join(tmpDir, "coverage.json")
+	require.NoError(t, os.WriteFile(coveragePyFile, []byte(`{"meta": {"version": "7.4.0"}, "files": {}}`), 0644))
+
+	emptyFile := filepath.Join(tmpDir, "empty.json")
+	require.NoError(t, os.WriteFile(emptyFile, []byte(`{}`), 0644))
+
+	assert.True(t, parser.SupportsFile(istanbulFile))
+	assert.False(t, parser.SupportsFile(coveragePyFile))
+	assert.False(t, parser.SupportsFile(emptyFile))
+}
@@ -0,0 +1,140 @@
+package parser_istanbul
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// processingOrchestrator is responsible for converting the raw Istanbul data
+// into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// processFiles is the main entry point for the orchestrator. Each report entry
+// describes exactly one source file, so files are processed independently and
+// returned sorted by path.
+func (o *processingOrchestrator) processFiles(files map[string]FileCoverageJSON) ([]parsers.FileCoverage, []string) {
+	filePaths := make([]string, 0, len(files))
+	for filePath := range files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+	for _, reportPath := range filePaths {
+		filePath := parsers.MapReportPath(o.config, filepath.ToSlash(reportPath))
+
+		if _, err := o.resolver.Resolve(filePath, []string{sourceDir}); err != nil {
+			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
+			unresolvedFiles = append(unresolvedFiles, filePath)
+		}
+
+		finalFileCoverage = append(finalFileCoverage, o.processFile(filePath, files[reportPath]))
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
+
+// processFile converts the statements, branches and functions of a single file.
+func (o *processingOrchestrator) processFile(filePath string, fileCov FileCoverageJSON) parsers.FileCoverage {
+	lineMetrics := make(map[int]model.LineMetrics)
+
+	for id, statement := range fileCov.StatementMap {
+		hits := fileCov.S[id]
+		// A statement can span multiple lines. As with Go cover blocks, its hit
+		// count applies to every line of its range, and when statements overlap
+		// the highest hit count wins.
+		for l := statement.Start.Line; l <= statement.End.Line; l++ {
+			if l <= 0 {
+				continue
+			}
+			if existing, ok := lineMetrics[l]; !ok || hits > existing.Hits {
+				lineMetrics[l] = model.LineMetrics{Hits: hits}
+			}
+		}
+	}
+
+	for id, branch := range fileCov.BranchMap {
+		o.applyBranch(lineMetrics, branch, fileCov.B[id])
+	}
+
+	return parsers.FileCoverage{
+		Path:    filePath,
+		Lines:   lineMetrics,
+		Methods: o.processFunctions(fileCov.FnMap),
+	}
+}
+
+// applyBranch adds the arms of a branch to the line the branch starts on. Each
+// arm counts as one branch, covered when its counter is greater than zero.
+func (o *processingOrchestrator) applyBranch(lineMetrics map[int]model.LineMetrics, branch BranchJSON, armHits []int) {
+	lineNumber := branch.Loc.Start.Line
+	if lineNumber <= 0 {
+		lineNumber = branch.Line
+	}
+
+	metric, ok := lineMetrics[lineNumber]
+	if !ok {
+		// Branches are only meaningful on coverable lines.
+		o.logger.Debug("Ignoring branch on a line without statements", "line", lineNumber, "type", branch.Type)
+		return
+	}
+
+	for _, hits := range armHits {
+		metric.TotalBranches++
+		if hits > 0 {
+			metric.CoveredBranches++
+		}
+	}
+	lineMetrics[lineNumber] = metric
+}
+
+// processFunctions converts "fnMap" into method boundaries. Istanbul records
+// the full range of every function, so no boundary inference is needed.
+func (o *processingOrchestrator) processFunctions(fnMap map[string]FunctionJSON) []model.MethodMetrics {
+	var methods []model.MethodMetrics
+	for _, function := range fnMap {
+		startLine, endLine := function.Loc.Start.Line, function.Loc.End.Line
+		if startLine <= 0 {
+			startLine, endLine = function.Decl.Start.Line, function.Decl.End.Line
+		}
+		if startLine <= 0 || function.Name == "" {
+			continue
+		}
+		methods = append(methods, model.MethodMetrics{
+			Name:      function.Name,
+			StartLine: startLine,
+			EndLine:   max(endLine, startLine),
+		})
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		if methods[i].StartLine != methods[j].StartLine {
+			return methods[i].StartLine < methods[j].StartLine
+		}
+		return methods[i].Name < methods[j].Name
+	})
+	return methods
+}
@@ -0,0 +1,70 @@
+package parser_jacoco
+
+import "encoding/xml"
+
+// <report>
+type ReportXML struct {
+	XMLName     xml.Name         `xml:"report"`
+	Name        string           `xml:"name,attr"`
+	SessionInfo []SessionInfoXML `xml:"sessioninfo"`
+	Groups      []GroupXML       `xml:"group"`
+	Packages    []PackageXML     `xml:"package"`
+}
+
+// <sessioninfo>
+type SessionInfoXML struct {
+	ID    string `xml:"id,attr"`
+	Start string `xml:"start,attr"`
+	Dump  string `xml:"dump,attr"`
+}
+
+// <group>, used by multi-module (aggregate) reports. Groups can be nested.
+type GroupXML struct {
+	Name     string       `xml:"name,attr"`
+	Groups   []GroupXML   `xml:"group"`
+	Packages []PackageXML `xml:"package"`
+}
+
+// <package>
+type PackageXML struct {
+	Name        string          `xml:"name,attr"`
+	Classes     []ClassXML      `xml:"class"`
+	SourceFiles []SourceFileXML `xml:"sourcefile"`
+}
+
+// <class>
+type ClassXML struct {
+	Name           string      `xml:"name,attr"`
+	SourceFileName string      `xml:"sourcefilename,attr"`
+	Methods        []MethodXML `xml:"method"`
+}
+
+// <method>
+type MethodXML struct {
+	Name     string       `xml:"name,attr"`
+	Desc     string       `xml:"desc,attr"`
+	Line     string       `xml:"line,attr"`
+	Counters []CounterXML `xml:"counter"`
+}
+
+// <sourcefile>
+type SourceFileXML struct {
+	Name  string    `xml:"name,attr"`
+	Lines []LineXML `xml:"line"`
+}
+
+// <line>
+type LineXML struct {
+	Number              string `xml:"nr,attr"`
+	MissedInstructions  string `xml:"mi,attr"`
+	CoveredInstructions string `xml:"ci,attr"`
+	MissedBranches      string `xml:"mb,attr"`
+	CoveredBranches     string `xml:"cb,attr"`
+}
+
+// <counter>
+type CounterXML struct {
+	Type    string `xml:"type,attr"`
+	Missed  string `xml:"missed,attr"`
+	Covered string `xml:"covered,attr"`
+}
@@ -0,0 +1,152 @@
+package parser_jacoco
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// JaCoCoParser implements the parsers.IParser interface for JaCoCo XML reports.
+type JaCoCoParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewJaCoCoParser creates a new parser instance.
+func NewJaCoCoParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &JaCoCoParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *JaCoCoParser) Name() string {
+	return "JaCoCo"
+}
+
+// SupportsFile performs a fast check to see if this parser can handle the given file.
+// It verifies the file has a ".xml" extension, declares the JaCoCo DOCTYPE and that
+// its root element is "<report>".
+func (p *JaCoCoParser) SupportsFile(filePath string) bool {
+	if !strings.HasSuffix(strings.ToLower(filePath), ".xml") {
+		return false
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	// The DOCTYPE directive always precedes the root element, so reading up
+	// to the first element is enough to identify the report.
+	decoder := xml.NewDecoder(f)
+	hasJaCoCoDoctype := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return false // Reached end of file without finding any elements.
+		}
+		if err != nil {
+			return false // Malformed XML.
+		}
+
+		switch t := token.(type) {
+		case xml.Directive:
+			if strings.Contains(strings.ToUpper(string(t)), "JACOCO") {
+				hasJaCoCoDoctype = true
+			}
+		case xml.StartElement:
+			return hasJaCoCoDoctype && t.Name.Local == "report"
+		}
+	}
+}
+
+// Parse unmarshals the JaCoCo XML report and delegates the conversion to a
+// flat list of FileCoverage objects to the processingOrchestrator.
+func (p *JaCoCoParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	rawReport, err := p.loadAndUnmarshalJaCoCoXML(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/unmarshal JaCoCo XML from %s: %w", filePath, err)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processPackages(collectPackages(rawReport.Packages, rawReport.Groups))
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		Timestamp:             p.getReportTimestamp(rawReport.SessionInfo, logger),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
+
+// ------ Helper Functions ------
+
+// collectPackages flattens the packages of a report, including the ones nested
+// in (possibly nested) groups of aggregate reports.
+func collectPackages(packages []PackageXML, groups []GroupXML) []PackageXML {
+	all := append([]PackageXML{}, packages...)
+	for _, group := range groups {
+		all = append(all, collectPackages(group.Packages, group.Groups)...)
+	}
+	return all
+}
+
+// getReportTimestamp uses the start time of the earliest session as the
+// coverage date. JaCoCo records session times in milliseconds.
+func (p *JaCoCoParser) getReportTimestamp(sessions []SessionInfoXML, logger *slog.Logger) *time.Time {
+	var earliest int64
+	for _, session := range sessions {
+		parsedTs, err := strconv.ParseInt(session.Start, 10, 64)
+		if err != nil {
+			logger.Warn("Failed to parse JaCoCo session start", "start", session.Start, "error", err)
+			continue
+		}
+		parsedTs /= 1000
+		if !utils.IsValidUnixSeconds(parsedTs) {
+			logger.Warn("JaCoCo session start is outside the valid range", "start", session.Start)
+			continue
+		}
+		if earliest == 0 || parsedTs < earliest {
+			earliest = parsedTs
+		}
+	}
+
+	if earliest == 0 {
+		return nil
+	}
+	t := time.Unix(earliest, 0)
+	return &t
+}
+
+// loadAndUnmarshalJaCoCoXML reads and unmarshals the JaCoCo XML file.
+func (p *JaCoCoParser) loadAndUnmarshalJaCoCoXML(path string) (*ReportXML, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	bytes, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	// The DOCTYPE references an external "report.dtd" that is never shipped
+	// with the report; encoding/xml does not resolve it, so it is harmless.
+	var rawReport ReportXML
+	if err := xml.Unmarshal(bytes, &rawReport); err != nil {
+		return nil, fmt.Errorf("unmarshal xml: %w", err)
+	}
+	return &rawReport, nil
+}
@@ -0,0 +1,179 @@
+package parser_jacoco_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_jacoco"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJaCoCoParser_Parse(t *testing.T) {
+	const reportFileName = "jacoco.xml"
+	const sourceDir = "/app/src/main/java"
+	const sourceFilePath = "com/example/Calculator.java" // Path derived from package + sourcefile
+	const resolvedSourcePath = "/app/src/main/java/com/example/Calculator.java"
+
+	testCases := []struct {
+		name          string
+		reportContent string
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name: "Golden Path - Lines, branches and methods",
+			reportContent: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="calculator">
+  <sessioninfo id="host-1" start="1672531200000" dump="1672531260000"/>
+  <package name="com/example">
+    <class name="com/example/Calculator" sourcefilename="Calculator.java">
+      <method name="&lt;init&gt;" desc="()V" line="3">
+        <counter type="COMPLEXITY" missed="0" covered="1"/>
+      </method>
+      <method name="sign" desc="(I)I" line="5">
+        <counter type="INSTRUCTION" missed="2" covered="6"/>
+        <counter type="BRANCH" missed="1" covered="3"/>
+        <counter type="COMPLEXITY" missed="1" covered="2"/>
+      </method>
+    </class>
+    <sourcefile name="Calculator.java">
+      <line nr="3" mi="0" ci="3" mb="0" cb="0"/>
+      <line nr="5" mi="0" ci="2" mb="0" cb="2"/>
+      <line nr="6" mi="0" ci="2" mb="0" cb="0"/>
+      <line nr="7" mi="0" ci="2" mb="1" cb="1"/>
+      <line nr="8" mi="2" ci="0" mb="0" cb="0"/>
+      <counter type="LINE" missed="1" covered="4"/>
+    </sourcefile>
+  </package>
+</report>`,
+			sourceFiles: map[string]string{
+				resolvedSourcePath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "JaCoCo", result.ParserName)
+				require.NotNil(t, result.Timestamp)
+				assert.Equal(t, int64(1672531200), result.Timestamp.Unix())
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, sourceFilePath, fileCov.Path)
+				require.Len(t, fileCov.Lines, 5)
+
+				assert.Equal(t, 3, fileCov.Lines[3].Hits)
+				assert.Equal(t, 2, fileCov.Lines[6].Hits)
+				assert.Equal(t, 0, fileCov.Lines[8].Hits)
+
+				assert.Equal(t, 2, fileCov.Lines[5].TotalBranches)
+				assert.Equal(t, 2, fileCov.Lines[5].CoveredBranches)
+				assert.Equal(t, 2, fileCov.Lines[7].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[7].CoveredBranches)
+
+				require.Len(t, fileCov.Methods, 2)
+				ctor, sign := fileCov.Methods[0], fileCov.Methods[1]
+
+				assert.Equal(t, "Calculator.<init>", ctor.Name)
+				assert.Equal(t, 3, ctor.StartLine)
+				assert.Equal(t, 3, ctor.EndLine, "A method ends at the last line before the next method")
+
+				assert.Equal(t, "Calculator.sign", sign.Name)
+				assert.Equal(t, 5, sign.StartLine)
+				assert.Equal(t, 8, sign.EndLine, "The last method ends at the last line of the file")
+				require.NotNil(t, sign.CyclomaticComplexity)
+				assert.Equal(t, 3, *sign.CyclomaticComplexity)
+			},
+		},
+		{
+			name: "Grouped report with the default package",
+			reportContent: `<?xml version="1.0" encoding="UTF-8"?><!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="aggregate">
+  <group name="module-a">
+    <package name="">
+      <sourcefile name="Main.java">
+        <line nr="1" mi="1" ci="0" mb="0" cb="0"/>
+      </sourcefile>
+    </package>
+  </group>
+</report>`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Nil(t, result.Timestamp)
+
+				require.Len(t, result.FileCoverage, 1)
+				assert.Equal(t, "Main.java", result.FileCoverage[0].Path)
+				assert.Equal(t, 0, result.FileCoverage[0].Lines[1].Hits)
+				assert.Empty(t, result.FileCoverage[0].Methods)
+
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, "Main.java", result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name: "Report is logically empty (no packages)",
+			reportContent: `<?xml version="1.0" encoding="UTF-8"?><!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd">
+<report name="empty"/>`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.FileCoverage, "Should produce no file coverage for an empty report")
+				assert.Empty(t, result.UnresolvedSourceFiles)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, reportFileName)
+			err := os.WriteFile(reportPath, []byte(tc.reportContent), 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_jacoco.NewJaCoCoParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
+
+func TestJaCoCoParser_SupportsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	parser := parser_jacoco.NewJaCoCoParser(utils.NewSourceResolver(testutil.NewMockFilesystem("unix"), nil))
+
+	jacocoFile := filepath.Join(tmpDir, "jacoco.xml")
+	require.NoError(t, os.WriteFile(jacocoFile, []byte(`<?xml version="1.0"?><!DOCTYPE report PUBLIC "-//JACOCO//DTD Report 1.1//EN" "report.dtd"><report name="x"/>`), 0644))
+
+	otherReportFile := filepath.Join(tmpDir, "other.xml")
+	require.NoError(t, os.WriteFile(otherReportFile, []byte(`<?xml version="1.0"?><report name="x"/>`), 0644))
+
+	coberturaFile := filepath.Join(tmpDir, "cobertura.xml")
+	require.NoError(t, os.WriteFile(coberturaFile, []byte(`<?xml version="1.0"?><coverage/>`), 0644))
+
+	assert.True(t, parser.SupportsFile(jacocoFile))
+	assert.False(t, parser.SupportsFile(otherReportFile), "A <report> root without the JaCoCo DOCTYPE is not JaCoCo")
+	assert.False(t, parser.SupportsFile(coberturaFile))
+}
@@ -0,0 +1,129 @@
+package parser_jacoco
+
+import (
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// processingOrchestrator is responsible for converting the raw JaCoCo XML data
+// into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// processPackages is the main entry point for the orchestrator. Line data in
+// JaCoCo lives in the <sourcefile> elements of a package, while method data
+// lives in its <class> elements; both are joined by the source file name.
+func (o *processingOrchestrator) processPackages(packages []PackageXML) ([]parsers.FileCoverage, []string) {
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	for _, pkgXML := range packages {
+		classesBySourceFile := make(map[string][]ClassXML)
+		for _, classXML := range pkgXML.Classes {
+			classesBySourceFile[classXML.SourceFileName] = append(classesBySourceFile[classXML.SourceFileName], classXML)
+		}
+
+		for _, sourceFileXML := range pkgXML.SourceFiles {
+			if sourceFileXML.Name == "" {
+				continue
+			}
+			// Package names use '/' as separator, so they map directly onto
+			// the directory layout of the sources.
+			filePath := parsers.MapReportPath(o.config, path.Join(pkgXML.Name, sourceFileXML.Name))
+
+			if _, err := o.resolver.Resolve(filePath, []string{sourceDir}); err != nil {
+				o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
+				unresolvedFiles = append(unresolvedFiles, filePath)
+			}
+
+			lines := o.processLines(sourceFileXML.Lines)
+			finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
+				Path:    filePath,
+				Lines:   lines,
+				Methods: o.processMethods(classesBySourceFile[sourceFileXML.Name], lines),
+			})
+		}
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
+
+// processLines converts the <line> elements of a source file into line metrics.
+// A line counts as hit when at least one of its instructions was covered, and
+// its branches come straight from the missed/covered branch counters.
+func (o *processingOrchestrator) processLines(linesXML []LineXML) map[int]model.LineMetrics {
+	lineMetrics := make(map[int]model.LineMetrics)
+	for _, lineXML := range linesXML {
+		lineNumber := utils.ParseInt(lineXML.Number, 0)
+		if lineNumber <= 0 {
+			continue
+		}
+
+		missedBranches := utils.ParseInt(lineXML.MissedBranches, 0)
+		coveredBranches := utils.ParseInt(lineXML.CoveredBranches, 0)
+
+		lineMetrics[lineNumber] = model.LineMetrics{
+			Hits:            utils.ParseInt(lineXML.CoveredInstructions, 0),
+			TotalBranches:   missedBranches + coveredBranches,
+			CoveredBranches: coveredBranches,
+		}
+	}
+	return lineMetrics
+}
+
+// processMethods pre-populates the method boundaries of a source file from the
+// <method> elements of its classes. JaCoCo only reports the first line of a
+// method, so the end lines are inferred from the line data of the file.
+func (o *processingOrchestrator) processMethods(classes []ClassXML, lines map[int]model.LineMetrics) []model.MethodMetrics {
+	var methods []model.MethodMetrics
+	for _, classXML := range classes {
+		className := classXML.Name[strings.LastIndex(classXML.Name, "/")+1:]
+		for _, methodXML := range classXML.Methods {
+			startLine := utils.ParseInt(methodXML.Line, 0)
+			if startLine <= 0 {
+				continue // Synthetic methods have no source line.
+			}
+			methods = append(methods, model.MethodMetrics{
+				Name:                 className + "." + methodXML.Name,
+				StartLine:            startLine,
+				CyclomaticComplexity: complexityFromCounters(methodXML.Counters),
+			})
+		}
+	}
+
+	parsers.InferMethodEndLines(methods, lines)
+	return methods
+}
+
+// complexityFromCounters extracts the cyclomatic complexity of a method from
+// its COMPLEXITY counter, which JaCoCo splits into missed and covered paths.
+func complexityFromCounters(counters []CounterXML) *int {
+	for _, counter := range counters {
+		if counter.Type == "COMPLEXITY" {
+			complexity := utils.ParseInt(counter.Missed, 0) + utils.ParseInt(counter.Covered, 0)
+			return &complexity
+		}
+	}
+	return nil
+}
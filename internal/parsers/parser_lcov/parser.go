@@ -0,0 +1,86 @@
+package parser_lcov
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/filereader"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// maxSniffedLines bounds how many lines SupportsFile reads while looking for
+// the first LCOV record, so that large unrelated files are rejected quickly.
+const maxSniffedLines = 20
+
+// supportedExtensions are the extensions tracefiles are written with by the
+// common tools: "lcov.info" (lcov, grcov, c8, coverlet) and "*.lcov".
+var supportedExtensions = []string{".info", ".lcov"}
+
+// LcovParser implements the parsers.IParser interface for LCOV tracefiles, as
+// produced by lcov/geninfo, grcov, c8/nyc, coverage.py, coverlet and others.
+type LcovParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewLcovParser creates a new parser instance.
+func NewLcovParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &LcovParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *LcovParser) Name() string {
+	return "LCOV"
+}
+
+// SupportsFile checks if the file is an LCOV tracefile: it must have one of
+// the supported extensions, and its first non-empty line must be a "TN:" or
+// "SF:" record.
+func (p *LcovParser) SupportsFile(filePath string) bool {
+	if !slices.Contains(supportedExtensions, strings.ToLower(filepath.Ext(filePath))) {
+		return false
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < maxSniffedLines && scanner.Scan(); i++ {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" {
+			continue
+		}
+		return strings.HasPrefix(line, "TN:") || strings.HasPrefix(line, "SF:")
+	}
+	return false
+}
+
+// Parse reads the tracefile from disk and delegates processing to the orchestrator.
+// Every "SF" section becomes its own FileCoverage entry.
+func (p *LcovParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	lines, err := filereader.ReadLinesInFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lcov file %s: %w", filePath, err)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processLines(lines)
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
@@ -0,0 +1,209 @@
+package parser_lcov_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_lcov"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLcovParser_Parse(t *testing.T) {
+	const reportFileName = "lcov.info"
+	const sourceDir = "/app/src"
+	const sourceFilePath = "/app/src/calc.c"
+
+	testCases := []struct {
+		name          string
+		reportContent string
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name: "Golden Path - Lines, branches and functions",
+			reportContent: `TN:unit
+SF:/app/src/calc.c
+FN:1,add
+FN:5,sign
+FNDA:3,add
+FNDA:1,sign
+FNF:2
+FNH:2
+DA:1,3
+DA:2,3
+DA:5,1
+DA:6,1
+DA:7,0
+DA:9,1
+BRDA:6,0,0,1
+BRDA:6,0,1,0
+BRDA:7,0,0,-
+BRDA:7,0,1,-
+BRF:4
+BRH:1
+LF:6
+LH:5
+end_of_record
+`,
+			sourceFiles: map[string]string{
+				sourceFilePath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "LCOV", result.ParserName)
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, sourceFilePath, fileCov.Path)
+				require.Len(t, fileCov.Lines, 6)
+
+				assert.Equal(t, 3, fileCov.Lines[1].Hits)
+				assert.Equal(t, 0, fileCov.Lines[7].Hits)
+
+				assert.Equal(t, 2, fileCov.Lines[6].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[6].CoveredBranches)
+				assert.Equal(t, 2, fileCov.Lines[7].TotalBranches)
+				assert.Equal(t, 0, fileCov.Lines[7].CoveredBranches, "'-' means the branch was never executed")
+
+				require.Len(t, fileCov.Methods, 2)
+				assert.Equal(t, "add", fileCov.Methods[0].Name)
+				assert.Equal(t, 1, fileCov.Methods[0].StartLine)
+				assert.Equal(t, 2, fileCov.Methods[0].EndLine)
+				assert.Equal(t, "sign", fileCov.Methods[1].Name)
+				assert.Equal(t, 5, fileCov.Methods[1].StartLine)
+				assert.Equal(t, 9, fileCov.Methods[1].EndLine)
+			},
+		},
+		{
+			name: "Repeated sections are merged and FN end lines are honored",
+			reportContent: `TN:first
+SF:/app/src/calc.c
+FN:1,4,add(int, int)
+DA:1,1
+DA:2,0
+BRDA:2,0,0,0
+end_of_record
+TN:second
+SF:/app/src/calc.c
+FN:1,4,add(int, int)
+DA:1,2
+DA:2,1
+BRDA:2,0,0,4
+end_of_record
+`,
+			sourceFiles: map[string]string{
+				sourceFilePath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+
+				assert.Equal(t, 3, fileCov.Lines[1].Hits, "Hits of repeated sections should be summed")
+				assert.Equal(t, 1, fileCov.Lines[2].Hits)
+				assert.Equal(t, 1, fileCov.Lines[2].TotalBranches, "The same branch should not be counted twice")
+				assert.Equal(t, 1, fileCov.Lines[2].CoveredBranches)
+
+				require.Len(t, fileCov.Methods, 1)
+				assert.Equal(t, "add(int, int)", fileCov.Methods[0].Name)
+				assert.Equal(t, 4, fileCov.Methods[0].EndLine)
+			},
+		},
+		{
+			name: "Unresolved source files are reported",
+			reportContent: `SF:lib/missing.c
+DA:3,1
+end_of_record
+SF:/app/src/calc.c
+DA:1,1
+end_of_record
+`,
+			sourceFiles: map[string]string{
+				sourceFilePath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 2)
+				assert.Equal(t, sourceFilePath, result.FileCoverage[0].Path, "Files should be sorted by path")
+				assert.Equal(t, "lib/missing.c", result.FileCoverage[1].Path)
+
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, "lib/missing.c", result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name:          "Tracefile is logically empty",
+			reportContent: "TN:\n",
+			sourceFiles:   map[string]string{},
+			sourceDirs:    []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.FileCoverage)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, reportFileName)
+			err := os.WriteFile(reportPath, []byte(tc.reportContent), 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_lcov.NewLcovParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
+
+func TestLcovParser_SupportsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	parser := parser_lcov.NewLcovParser(utils.NewSourceResolver(testutil.NewMockFilesystem("unix"), nil))
+
+	lcovFile := filepath.Join(tmpDir, "lcov.info")
+	require.NoError(t, os.WriteFile(lcovFile, []byte("\nSF:main.c\nDA:1,1\nend_of_record\n"), 0644))
+
+	dotLcovFile := filepath.Join(tmpDir, "coverage.LCOV")
+	require.NoError(t, os.WriteFile(dotLcovFile, []byte("TN:\nSF:main.c\nend_of_record\n"), 0644))
+
+	goCoverFile := filepath.Join(tmpDir, "coverage.out")
+	require.NoError(t, os.WriteFile(goCoverFile, []byte("mode: set\n"), 0644))
+
+	wrongExtensionFile := filepath.Join(tmpDir, "coverage.txt")
+	require.NoError(t, os.WriteFile(wrongExtensionFile, []byte("SF:main.c\nDA:1,1\nend_of_record\n"), 0644))
+
+	notLcovFile := filepath.Join(tmpDir, "notes.info")
+	require.NoError(t, os.WriteFile(notLcovFile, []byte("This is not a tracefile.\n"), 0644))
+
+	assert.True(t, parser.SupportsFile(lcovFile))
+	assert.True(t, parser.SupportsFile(dotLcovFile))
+	assert.False(t, parser.SupportsFile(goCoverFile))
+	assert.False(t, parser.SupportsFile(wrongExtensionFile), "Files without a tracefile extension should be rejected")
+	assert.False(t, parser.SupportsFile(notLcovFile), "Files that do not start with a record should be rejected")
+	assert.False(t, parser.SupportsFile(filepath.Join(tmpDir, "missing.info")))
+}
@@ -0,0 +1,233 @@
+package parser_lcov
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// processingOrchestrator is responsible for converting the records of an LCOV
+// tracefile into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// fileAccumulator collects the records of every section that refers to the same
+// source file. Tracefiles merged from several test runs (or with several "TN"
+// test names) may repeat a file, in which case the hits are summed.
+type fileAccumulator struct {
+	lines    map[int]int
+	branches map[int]map[string]bool // line -> "block,branch" -> taken
+	methods  map[string]model.MethodMetrics
+}
+
+func newFileAccumulator() *fileAccumulator {
+	return &fileAccumulator{
+		lines:    make(map[int]int),
+		branches: make(map[int]map[string]bool),
+		methods:  make(map[string]model.MethodMetrics),
+	}
+}
+
+// processLines walks the tracefile records. Only the records that carry data
+// are interpreted (SF, DA, BRDA, FN); the summary records (LF, LH, BRF, BRH,
+// FNF, FNH) are redundant and FNDA hit counts are recomputed from line data.
+func (o *processingOrchestrator) processLines(lines []string) ([]parsers.FileCoverage, []string) {
+	accumulators := make(map[string]*fileAccumulator)
+	var current *fileAccumulator
+
+	for lineIndex, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line == "end_of_record" {
+			current = nil
+			continue
+		}
+
+		tag, value, found := strings.Cut(line, ":")
+		if !found {
+			o.logger.Debug("Skipping unrecognized LCOV line", "line", lineIndex+1)
+			continue
+		}
+
+		if tag == "SF" {
+			filePath := filepath.ToSlash(value)
+			if _, ok := accumulators[filePath]; !ok {
+				accumulators[filePath] = newFileAccumulator()
+			}
+			current = accumulators[filePath]
+			continue
+		}
+		if current == nil {
+			continue // Records outside of an SF section (e.g. TN) carry no coverage.
+		}
+
+		switch tag {
+		case "DA":
+			o.processLineRecord(current, value, lineIndex)
+		case "BRDA":
+			o.processBranchRecord(current, value, lineIndex)
+		case "FN":
+			o.processFunctionRecord(current, value, lineIndex)
+		}
+	}
+
+	return o.finalize(accumulators)
+}
+
+// processLineRecord handles "DA:<line>,<hits>[,<checksum>]".
+func (o *processingOrchestrator) processLineRecord(acc *fileAccumulator, value string, lineIndex int) {
+	parts := strings.Split(value, ",")
+	if len(parts) < 2 {
+		o.logger.Warn("Malformed DA record", "line", lineIndex+1)
+		return
+	}
+	lineNumber := utils.ParseInt(parts[0], 0)
+	if lineNumber <= 0 {
+		return
+	}
+	// Some generators emit negative or fractional counts; clamp them to 0.
+	hits := max(utils.ParseInt(parts[1], 0), 0)
+	acc.lines[lineNumber] += hits
+}
+
+// processBranchRecord handles "BRDA:<line>,<block>,<branch>,<taken>", where
+// taken is "-" when the enclosing block was never executed.
+func (o *processingOrchestrator) processBranchRecord(acc *fileAccumulator, value string, lineIndex int) {
+	parts := strings.Split(value, ",")
+	if len(parts) < 4 {
+		o.logger.Warn("Malformed BRDA record", "line", lineIndex+1)
+		return
+	}
+	lineNumber := utils.ParseInt(parts[0], 0)
+	if lineNumber <= 0 {
+		return
+	}
+
+	// Newer lcov versions may add an "e" exception marker to the block or the
+	// branch, so the whole middle section is used as the identity.
+	branchID := strings.Join(parts[1:len(parts)-1], ",")
+	taken := parts[len(parts)-1]
+	covered := taken != "-" && utils.ParseInt(taken, 0) > 0
+
+	if acc.branches[lineNumber] == nil {
+		acc.branches[lineNumber] = make(map[string]bool)
+	}
+	acc.branches[lineNumber][branchID] = acc.branches[lineNumber][branchID] || covered
+}
+
+// processFunctionRecord handles both "FN:<line>,<name>" and the lcov 2.x form
+// "FN:<start>,<end>,<name>". Function names may themselves contain commas
+// (C++ signatures), so the end line is only taken when it is numeric.
+func (o *processingOrchestrator) processFunctionRecord(acc *fileAccumulator, value string, lineIndex int) {
+	startText, rest, found := strings.Cut(value, ",")
+	if !found {
+		o.logger.Warn("Malformed FN record", "line", lineIndex+1)
+		return
+	}
+	startLine := utils.ParseInt(startText, 0)
+	if startLine <= 0 {
+		return
+	}
+
+	endLine := 0
+	name := rest
+	if endText, afterEnd, ok := strings.Cut(rest, ","); ok {
+		if parsedEnd := utils.ParseInt(endText, -1); parsedEnd >= startLine {
+			endLine = parsedEnd
+			name = afterEnd
+		}
+	}
+	if name == "" {
+		return
+	}
+
+	acc.methods[name] = model.MethodMetrics{
+		Name:      name,
+		StartLine: startLine,
+		EndLine:   endLine,
+	}
+}
+
+// finalize converts the accumulated data into FileCoverage entries, sorted by
+// path, and checks that every source file can be located.
+func (o *processingOrchestrator) finalize(accumulators map[string]*fileAccumulator) ([]parsers.FileCoverage, []string) {
+	filePaths := make([]string, 0, len(accumulators))
+	for filePath := range accumulators {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+	for _, reportPath := range filePaths {
+		acc := accumulators[reportPath]
+		filePath := parsers.MapReportPath(o.config, reportPath)
+
+		if _, err := o.resolver.Resolve(filePath, []string{sourceDir}); err != nil {
+			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
+			unresolvedFiles = append(unresolvedFiles, filePath)
+		}
+
+		lineMetrics := make(map[int]model.LineMetrics, len(acc.lines))
+		for lineNumber, hits := range acc.lines {
+			lineMetrics[lineNumber] = model.LineMetrics{Hits: hits}
+		}
+		// Branches are only meaningful on coverable lines.
+		for lineNumber, branches := range acc.branches {
+			metric, ok := lineMetrics[lineNumber]
+			if !ok {
+				continue
+			}
+			for _, covered := range branches {
+				metric.TotalBranches++
+				if covered {
+					metric.CoveredBranches++
+				}
+			}
+			lineMetrics[lineNumber] = metric
+		}
+
+		methods := make([]model.MethodMetrics, 0, len(acc.methods))
+		for _, method := range acc.methods {
+			methods = append(methods, method)
+		}
+		sort.Slice(methods, func(i, j int) bool {
+			if methods[i].StartLine != methods[j].StartLine {
+				return methods[i].StartLine < methods[j].StartLine
+			}
+			return methods[i].Name < methods[j].Name
+		})
+		parsers.InferMethodEndLines(methods, lineMetrics)
+
+		finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
+			Path:    filePath,
+			Lines:   lineMetrics,
+			Methods: methods,
+		})
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
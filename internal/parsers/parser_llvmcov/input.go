@@ -0,0 +1,133 @@
+package parser_llvmcov
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExportJSON is the root of an "llvm-cov export -format=text" report.
+type ExportJSON struct {
+	Type    string       `json:"type"`
+	Version string       `json:"version"`
+	Data    []ExportData `json:"data"`
+}
+
+// ExportData holds one export object; llvm-cov writes exactly one, but the
+// format allows several.
+type ExportData struct {
+	Files     []FileJSON     `json:"files"`
+	Functions []FunctionJSON `json:"functions"`
+}
+
+// FileJSON is the coverage of a single source file.
+type FileJSON struct {
+	Filename string        `json:"filename"`
+	Segments []SegmentJSON `json:"segments"`
+	Branches []BranchJSON  `json:"branches"`
+}
+
+// FunctionJSON is a single function record. The fileID of each region indexes
+// Filenames; the function name is the (usually mangled) symbol name.
+type FunctionJSON struct {
+	Name      string       `json:"name"`
+	Count     int64        `json:"count"`
+	Regions   []RegionJSON `json:"regions"`
+	Filenames []string     `json:"filenames"`
+}
+
+// SegmentJSON is encoded as [line, col, count, hasCount, isRegionEntry,
+// isGapRegion]. The gap flag only exists since export format 2.0.1.
+type SegmentJSON struct {
+	Line          int
+	Col           int
+	Count         int64
+	HasCount      bool
+	IsRegionEntry bool
+	IsGapRegion   bool
+}
+
+// BranchJSON is encoded as [lineStart, colStart, lineEnd, colEnd, trueCount,
+// falseCount, fileID, expandedFileID, kind].
+type BranchJSON struct {
+	LineStart  int
+	ColStart   int
+	LineEnd    int
+	ColEnd     int
+	TrueCount  int64
+	FalseCount int64
+}
+
+// RegionJSON is encoded as [lineStart, colStart, lineEnd, colEnd, count,
+// fileID, expandedFileID, kind].
+type RegionJSON struct {
+	LineStart int
+	ColStart  int
+	LineEnd   int
+	ColEnd    int
+	Count     int64
+	FileID    int
+	Kind      int
+}
+
+// UnmarshalJSON decodes the array form of a segment.
+func (s *SegmentJSON) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 5 {
+		return fmt.Errorf("segment has %d fields, expected at least 5", len(raw))
+	}
+
+	fields := []any{&s.Line, &s.Col, &s.Count, &s.HasCount, &s.IsRegionEntry}
+	if len(raw) > 5 {
+		fields = append(fields, &s.IsGapRegion)
+	}
+	for i, field := range fields {
+		if err := json.Unmarshal(raw[i], field); err != nil {
+			return fmt.Errorf("segment field %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the array form of a branch.
+func (b *BranchJSON) UnmarshalJSON(data []byte) error {
+	var raw []int64
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 6 {
+		return fmt.Errorf("branch has %d fields, expected at least 6", len(raw))
+	}
+	*b = BranchJSON{
+		LineStart:  int(raw[0]),
+		ColStart:   int(raw[1]),
+		LineEnd:    int(raw[2]),
+		ColEnd:     int(raw[3]),
+		TrueCount:  raw[4],
+		FalseCount: raw[5],
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes the array form of a region.
+func (r *RegionJSON) UnmarshalJSON(data []byte) error {
+	var raw []int64
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 8 {
+		return fmt.Errorf("region has %d fields, expected 8", len(raw))
+	}
+	*r = RegionJSON{
+		LineStart: int(raw[0]),
+		ColStart:  int(raw[1]),
+		LineEnd:   int(raw[2]),
+		ColEnd:    int(raw[3]),
+		Count:     raw[4],
+		FileID:    int(raw[5]),
+		Kind:      int(raw[7]),
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package parser_llvmcov
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// exportType is the value of the root "type" key of every llvm-cov export.
+const exportType = "llvm.coverage.json.export"
+
+// sniffTailSize is how much of the end of a file SupportsFile inspects. llvm-cov
+// writes the root keys in sorted order, so "type" follows the (large) "data"
+// array and always sits at the very end of the report.
+const sniffTailSize = 4096
+
+// LlvmCovParser implements the parsers.IParser interface for the JSON reports
+// written by "llvm-cov export" for clang source-based coverage.
+type LlvmCovParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewLlvmCovParser creates a new parser instance.
+func NewLlvmCovParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &LlvmCovParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *LlvmCovParser) Name() string {
+	return "LlvmCov"
+}
+
+// SupportsFile performs a fast check to see if this parser can handle the given file.
+// It verifies the file has a ".json" extension, starts with an object and
+// declares the llvm-cov export type near its end.
+func (p *LlvmCovParser) SupportsFile(filePath string) bool {
+	if !strings.HasSuffix(strings.ToLower(filePath), ".json") {
+		return false
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	head := make([]byte, 64)
+	n, err := f.Read(head)
+	if err != nil || !bytes.HasPrefix(bytes.TrimSpace(head[:n]), []byte("{")) {
+		return false
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	offset := max(info.Size()-sniffTailSize, 0)
+	tail := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return false
+	}
+	return bytes.Contains(tail, []byte(exportType))
+}
+
+// Parse unmarshals the llvm-cov export and delegates the conversion to a flat
+// list of FileCoverage objects to the processingOrchestrator.
+func (p *LlvmCovParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	rawReport, err := p.loadAndUnmarshalExportJSON(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/unmarshal llvm-cov JSON from %s: %w", filePath, err)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processExports(rawReport.Data)
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
+
+// loadAndUnmarshalExportJSON reads and unmarshals the llvm-cov export file.
+func (p *LlvmCovParser) loadAndUnmarshalExportJSON(path string) (*ExportJSON, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var rawReport ExportJSON
+	if err := json.Unmarshal(content, &rawReport); err != nil {
+		return nil, fmt.Errorf("unmarshal json: %w", err)
+	}
+	if rawReport.Type != exportType {
+		return nil, fmt.Errorf("unexpected export type %q", rawReport.Type)
+	}
+	return &rawReport, nil
+}
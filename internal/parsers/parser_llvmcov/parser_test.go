@@ -0,0 +1,170 @@
+package parser_llvmcov_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_llvmcov"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLlvmCovParser_Parse(t *testing.T) {
+	const reportFileName = "coverage.json"
+	const sourceDir = "/app/src"
+	const sourceFilePath = "/app/src/sign.cpp"
+
+	testCases := []struct {
+		name          string
+		reportContent string
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name: "Golden Path - Segments, mid-line regions, branches and functions",
+			reportContent: `{"data":[{"files":[{"filename":"/app/src/sign.cpp",
+"segments":[
+  [1,17,4,true,true,false],
+  [2,13,1,true,false,true],
+  [3,5,1,true,true,false],
+  [3,15,4,true,false,false],
+  [4,18,3,true,true,false],
+  [4,19,4,true,false,false],
+  [4,22,0,true,true,false],
+  [4,23,4,true,false,false],
+  [5,2,0,false,false,false],
+  [7,14,0,true,true,false],
+  [7,27,0,false,false,false],
+  [9,13,1,true,true,false],
+  [9,35,3,true,true,false],
+  [9,40,1,true,false,false],
+  [9,43,0,false,false,false],
+  [11,1,0,false,true,false],
+  [13,1,0,false,false,false]
+],
+"branches":[
+  [2,7,2,12,1,3,0,0,4],
+  [9,25,9,30,3,0,0,0,4]
+],
+"expansions":[],
+"summary":{}}],
+"functions":[
+  {"name":"_Z4signi","count":4,"regions":[[1,17,5,2,4,0,0,0],[2,13,3,5,1,0,0,3],[3,5,3,15,1,0,0,0]],"branches":[],"filenames":["/app/src/sign.cpp"]},
+  {"name":"_Z6unusedv","count":0,"regions":[[7,14,7,27,0,0,0,0]],"branches":[],"filenames":["/app/src/sign.cpp"]}
+],
+"totals":{}}],
+"type":"llvm.coverage.json.export","version":"2.0.1"}`,
+			sourceFiles: map[string]string{
+				sourceFilePath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "LlvmCov", result.ParserName)
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, sourceFilePath, fileCov.Path)
+				require.Len(t, fileCov.Lines, 7, "Only lines 1-5, 7 and 9 are mapped")
+
+				assert.Equal(t, 4, fileCov.Lines[1].Hits)
+				assert.Equal(t, 4, fileCov.Lines[2].Hits)
+				assert.Equal(t, 1, fileCov.Lines[3].Hits, "The gap region wrapping into the line sets its count")
+				assert.Equal(t, 4, fileCov.Lines[4].Hits)
+				assert.Equal(t, 4, fileCov.Lines[5].Hits)
+				assert.Equal(t, 0, fileCov.Lines[7].Hits)
+				assert.Equal(t, 3, fileCov.Lines[9].Hits, "A region starting mid-line should raise the line count")
+				assert.NotContains(t, fileCov.Lines, 12, "Lines in skipped regions are not coverable")
+
+				assert.Equal(t, 2, fileCov.Lines[2].TotalBranches)
+				assert.Equal(t, 2, fileCov.Lines[2].CoveredBranches)
+				assert.Equal(t, 2, fileCov.Lines[9].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[9].CoveredBranches)
+
+				require.Len(t, fileCov.Methods, 2)
+				assert.Equal(t, "_Z4signi", fileCov.Methods[0].Name)
+				assert.Equal(t, 1, fileCov.Methods[0].StartLine)
+				assert.Equal(t, 5, fileCov.Methods[0].EndLine)
+				assert.Equal(t, "_Z6unusedv", fileCov.Methods[1].Name)
+				assert.Equal(t, 7, fileCov.Methods[1].StartLine)
+				assert.Equal(t, 7, fileCov.Methods[1].EndLine)
+			},
+		},
+		{
+			name: "Pre-2.0.1 segments without gap flag and an unresolved file",
+			reportContent: `{"data":[{"files":[{"filename":"lib/util.c",
+"segments":[[1,12,2,true,true],[3,2,0,false,false]],
+"branches":[]}],"functions":[]}],
+"type":"llvm.coverage.json.export","version":"2.0.0"}`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, "lib/util.c", fileCov.Path)
+				require.Len(t, fileCov.Lines, 3)
+				assert.Equal(t, 2, fileCov.Lines[1].Hits)
+				assert.Equal(t, 2, fileCov.Lines[3].Hits)
+
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, "lib/util.c", result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name:          "Wrong export type",
+			reportContent: `{"data":[],"type":"something.else","version":"1.0"}`,
+			sourceFiles:   map[string]string{},
+			sourceDirs:    []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.Error(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, reportFileName)
+			err := os.WriteFile(reportPath, []byte(tc.reportContent), 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_llvmcov.NewLlvmCovParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
+
+func TestLlvmCovParser_SupportsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	parser := parser_llvmcov.NewLlvmCovParser(utils.NewSourceResolver(testutil.NewMockFilesystem("unix"), nil))
+
+	llvmFile := filepath.Join(tmpDir, "coverage.json")
+	require.NoError(t, os.WriteFile(llvmFile, []byte(`{"data":[],"type":"llvm.coverage.json.export","version":"2.0.1"}`), 0644))
+
+	istanbulFile := filepath.Join(tmpDir, "coverage-final.json")
+	require.NoError(t, os.WriteFile(istanbulFile, []byte(`{"/app/a.js": {"path": "/app/a.js", "statementMap": {}}}`), 0644))
+
+	assert.True(t, parser.SupportsFile(llvmFile))
+	assert.False(t, parser.SupportsFile(istanbulFile))
+}
@@ -0,0 +1,217 @@
+package parser_llvmcov
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// codeRegionKind is the kind of regions that map actual code, as opposed to
+// expansion, skipped, gap and branch regions.
+const codeRegionKind = 0
+
+// processingOrchestrator is responsible for converting the raw llvm-cov data
+// into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// processExports is the main entry point for the orchestrator. Files that show
+// up in several export objects are merged by summing their hits.
+func (o *processingOrchestrator) processExports(exports []ExportData) ([]parsers.FileCoverage, []string) {
+	linesByFile := make(map[string]map[int]model.LineMetrics)
+	methodsByFile := make(map[string][]model.MethodMetrics)
+
+	for _, export := range exports {
+		for _, fileJSON := range export.Files {
+			filePath := filepath.ToSlash(fileJSON.Filename)
+			if filePath == "" {
+				continue
+			}
+			if linesByFile[filePath] == nil {
+				linesByFile[filePath] = make(map[int]model.LineMetrics)
+			}
+			mergeLines(linesByFile[filePath], o.processFile(fileJSON))
+		}
+		for _, function := range export.Functions {
+			if filePath, method, ok := o.processFunction(function); ok {
+				methodsByFile[filePath] = append(methodsByFile[filePath], method)
+			}
+		}
+	}
+
+	filePaths := make([]string, 0, len(linesByFile))
+	for filePath := range linesByFile {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+	for _, reportPath := range filePaths {
+		filePath := parsers.MapReportPath(o.config, reportPath)
+		if _, err := o.resolver.Resolve(filePath, []string{sourceDir}); err != nil {
+			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", filePath, "error", err)
+			unresolvedFiles = append(unresolvedFiles, filePath)
+		}
+
+		methods := methodsByFile[reportPath]
+		sort.SliceStable(methods, func(i, j int) bool {
+			return methods[i].StartLine < methods[j].StartLine
+		})
+		finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
+			Path:    filePath,
+			Lines:   linesByFile[reportPath],
+			Methods: methods,
+		})
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
+
+// processFile rebuilds the line metrics of a single file from its segments and
+// attaches the branch counts to the line each branch starts on.
+func (o *processingOrchestrator) processFile(fileJSON FileJSON) map[int]model.LineMetrics {
+	lineMetrics := lineMetricsFromSegments(fileJSON.Segments)
+
+	for _, branch := range fileJSON.Branches {
+		metric, ok := lineMetrics[branch.LineStart]
+		if !ok {
+			continue // Branches are only meaningful on coverable lines.
+		}
+		// Every branch region has a true and a false outcome.
+		metric.TotalBranches += 2
+		if branch.TrueCount > 0 {
+			metric.CoveredBranches++
+		}
+		if branch.FalseCount > 0 {
+			metric.CoveredBranches++
+		}
+		lineMetrics[branch.LineStart] = metric
+	}
+	return lineMetrics
+}
+
+// lineMetricsFromSegments computes per-line hit counts the same way llvm-cov
+// does for its own line-oriented views (LineCoverageStats).
+//
+// A segment marks the point where the count of the code changes. For every
+// line, the "wrapped" segment is the one still in effect from previous lines,
+// and the line segments are the ones starting on the line itself, possibly in
+// the middle of it. A line is coverable when a counted region wraps into it or
+// starts on it (unless it starts a skipped region), and its hit count is the
+// highest count among the wrapped segment and the regions starting on it.
+func lineMetricsFromSegments(segments []SegmentJSON) map[int]model.LineMetrics {
+	lineMetrics := make(map[int]model.LineMetrics)
+	if len(segments) == 0 {
+		return lineMetrics
+	}
+
+	sort.SliceStable(segments, func(i, j int) bool {
+		if segments[i].Line != segments[j].Line {
+			return segments[i].Line < segments[j].Line
+		}
+		return segments[i].Col < segments[j].Col
+	})
+
+	isStartOfRegion := func(s SegmentJSON) bool {
+		return !s.IsGapRegion && s.HasCount && s.IsRegionEntry
+	}
+
+	var wrapped *SegmentJSON
+	next := 0
+	lastLine := segments[len(segments)-1].Line
+	for line := segments[0].Line; line <= lastLine; line++ {
+		start := next
+		for next < len(segments) && segments[next].Line == line {
+			next++
+		}
+		lineSegments := segments[start:next]
+
+		regionStarts := 0
+		for _, s := range lineSegments {
+			if isStartOfRegion(s) {
+				regionStarts++
+			}
+		}
+		startsSkippedRegion := len(lineSegments) > 0 && !lineSegments[0].HasCount && lineSegments[0].IsRegionEntry
+		mapped := !startsSkippedRegion && ((wrapped != nil && wrapped.HasCount) || regionStarts > 0)
+
+		if mapped {
+			var count int64
+			if wrapped != nil {
+				count = wrapped.Count
+			}
+			for _, s := range lineSegments {
+				if isStartOfRegion(s) {
+					count = max(count, s.Count)
+				}
+			}
+			lineMetrics[line] = model.LineMetrics{Hits: int(count)}
+		}
+
+		if len(lineSegments) > 0 {
+			wrapped = &lineSegments[len(lineSegments)-1]
+		}
+	}
+	return lineMetrics
+}
+
+// processFunction converts a function record into a method of the file its
+// body lives in. The boundaries are taken from its code regions in that file.
+func (o *processingOrchestrator) processFunction(function FunctionJSON) (string, model.MethodMetrics, bool) {
+	if function.Name == "" || len(function.Filenames) == 0 {
+		return "", model.MethodMetrics{}, false
+	}
+
+	startLine, endLine := 0, 0
+	for _, region := range function.Regions {
+		if region.FileID != 0 || region.Kind != codeRegionKind || region.LineStart <= 0 {
+			continue
+		}
+		if startLine == 0 || region.LineStart < startLine {
+			startLine = region.LineStart
+		}
+		endLine = max(endLine, region.LineEnd)
+	}
+	if startLine == 0 {
+		return "", model.MethodMetrics{}, false
+	}
+
+	return filepath.ToSlash(function.Filenames[0]), model.MethodMetrics{
+		Name:      function.Name,
+		StartLine: startLine,
+		EndLine:   max(endLine, startLine),
+	}, true
+}
+
+// mergeLines adds the metrics of one occurrence of a file to the metrics
+// already collected for it.
+func mergeLines(target, source map[int]model.LineMetrics) {
+	for lineNumber, metric := range source {
+		existing := target[lineNumber]
+		existing.Hits += metric.Hits
+		existing.CoveredBranches = max(existing.CoveredBranches, metric.CoveredBranches)
+		existing.TotalBranches = max(existing.TotalBranches, metric.TotalBranches)
+		target[lineNumber] = existing
+	}
+}
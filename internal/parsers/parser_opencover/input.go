@@ -0,0 +1,94 @@
+package parser_opencover
+
+import "encoding/xml"
+
+// <CoverageSession>
+type CoverageSession struct {
+	XMLName xml.Name   `xml:"CoverageSession"`
+	Modules ModulesXML `xml:"Modules"`
+}
+
+// <Modules>
+type ModulesXML struct {
+	Module []ModuleXML `xml:"Module"`
+}
+
+// <Module>
+type ModuleXML struct {
+	SkippedDueTo string     `xml:"skippedDueTo,attr"`
+	ModuleName   string     `xml:"ModuleName"`
+	Files        FilesXML   `xml:"Files"`
+	Classes      ClassesXML `xml:"Classes"`
+}
+
+// <Files>
+type FilesXML struct {
+	File []FileXML `xml:"File"`
+}
+
+// <File>
+type FileXML struct {
+	UID      string `xml:"uid,attr"`
+	FullPath string `xml:"fullPath,attr"`
+}
+
+// <Classes>
+type ClassesXML struct {
+	Class []ClassXML `xml:"Class"`
+}
+
+// <Class>
+type ClassXML struct {
+	SkippedDueTo string     `xml:"skippedDueTo,attr"`
+	FullName     string     `xml:"FullName"`
+	Methods      MethodsXML `xml:"Methods"`
+}
+
+// <Methods>
+type MethodsXML struct {
+	Method []MethodXML `xml:"Method"`
+}
+
+// <Method>
+type MethodXML struct {
+	SkippedDueTo         string            `xml:"skippedDueTo,attr"`
+	CyclomaticComplexity string            `xml:"cyclomaticComplexity,attr"`
+	Name                 string            `xml:"Name"`
+	FileRef              FileRefXML        `xml:"FileRef"`
+	SequencePoints       SequencePointsXML `xml:"SequencePoints"`
+	BranchPoints         BranchPointsXML   `xml:"BranchPoints"`
+}
+
+// <FileRef>
+type FileRefXML struct {
+	UID string `xml:"uid,attr"`
+}
+
+// <SequencePoints>
+type SequencePointsXML struct {
+	SequencePoint []SequencePointXML `xml:"SequencePoint"`
+}
+
+// <SequencePoint>
+type SequencePointXML struct {
+	VisitCount string `xml:"vc,attr"`
+	StartLine  string `xml:"sl,attr"`
+	EndLine    string `xml:"el,attr"`
+	FileID     string `xml:"fileid,attr"`
+}
+
+// <BranchPoints>
+type BranchPointsXML struct {
+	BranchPoint []BranchPointXML `xml:"BranchPoint"`
+}
+
+// <BranchPoint>
+type BranchPointXML struct {
+	VisitCount string `xml:"vc,attr"`
+	UspID      string `xml:"uspid,attr"`
+	Path       string `xml:"path,attr"`
+	Offset     string `xml:"offset,attr"`
+	OffsetEnd  string `xml:"offsetend,attr"`
+	StartLine  string `xml:"sl,attr"`
+	FileID     string `xml:"fileid,attr"`
+}
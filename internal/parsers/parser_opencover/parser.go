@@ -0,0 +1,101 @@
+package parser_opencover
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// OpenCoverParser implements the parsers.IParser interface for OpenCover XML reports,
+// as produced by OpenCover itself and by coverlet's "opencover" output format.
+type OpenCoverParser struct {
+	resolver *utils.SourceResolver
+}
+
+// NewOpenCoverParser creates a new parser instance.
+func NewOpenCoverParser(resolver *utils.SourceResolver) parsers.IParser {
+	return &OpenCoverParser{
+		resolver: resolver,
+	}
+}
+
+// Name returns the unique, human-readable name of the parser.
+func (p *OpenCoverParser) Name() string {
+	return "OpenCover"
+}
+
+// SupportsFile performs a fast check to see if this parser can handle the given file.
+// It verifies the file has a ".xml" extension and that its root element is "<CoverageSession>".
+func (p *OpenCoverParser) SupportsFile(filePath string) bool {
+	if !strings.HasSuffix(strings.ToLower(filePath), ".xml") {
+		return false
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	// We only need to check the very first element to identify the report type.
+	decoder := xml.NewDecoder(f)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return false // Reached end of file without finding any elements.
+		}
+		if err != nil {
+			return false // Malformed XML.
+		}
+
+		if se, ok := token.(xml.StartElement); ok {
+			return se.Name.Local == "CoverageSession"
+		}
+	}
+}
+
+// Parse unmarshals the OpenCover XML report and delegates the conversion to a
+// flat list of FileCoverage objects to the processingOrchestrator.
+func (p *OpenCoverParser) Parse(filePath string, config parsers.ParserConfig) (*parsers.ParserResult, error) {
+	logger := config.Logger().With(slog.String("parser", p.Name()), slog.String("file", filePath))
+
+	rawReport, err := p.loadAndUnmarshalOpenCoverXML(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load/unmarshal OpenCover XML from %s: %w", filePath, err)
+	}
+
+	orchestrator := newProcessingOrchestrator(p.resolver, config, logger)
+	fileCoverage, unresolvedFiles := orchestrator.processModules(rawReport.Modules.Module)
+
+	return &parsers.ParserResult{
+		FileCoverage:          fileCoverage,
+		ParserName:            p.Name(),
+		UnresolvedSourceFiles: unresolvedFiles,
+	}, nil
+}
+
+// loadAndUnmarshalOpenCoverXML reads and unmarshals the OpenCover XML file.
+func (p *OpenCoverParser) loadAndUnmarshalOpenCoverXML(path string) (*CoverageSession, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	bytes, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var rawReport CoverageSession
+	if err := xml.Unmarshal(bytes, &rawReport); err != nil {
+		return nil, fmt.Errorf("unmarshal xml: %w", err)
+	}
+	return &rawReport, nil
+}
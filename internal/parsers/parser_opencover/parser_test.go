@@ -0,0 +1,227 @@
+package parser_opencover_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_opencover"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenCoverParser_Parse(t *testing.T) {
+	const reportFileName = "coverage.opencover.xml"
+	const sourceDir = "/app/src"
+	const reportedPath = "/app/src/Test/Calculator.cs" // Path as it appears in the report
+
+	testCases := []struct {
+		name          string
+		reportContent string
+		sourceFiles   map[string]string // map[path]content, for mock filesystem
+		sourceDirs    []string
+		asserter      func(t *testing.T, result *parsers.ParserResult, err error)
+	}{
+		{
+			name: "Golden Path - Sequence points and branch points",
+			reportContent: `<?xml version="1.0" encoding="utf-8"?>
+<CoverageSession>
+  <Modules>
+    <Module hash="ABC">
+      <ModuleName>Test</ModuleName>
+      <Files>
+        <File uid="1" fullPath="/app/src/Test/Calculator.cs" />
+      </Files>
+      <Classes>
+        <Class>
+          <FullName>Test.Calculator</FullName>
+          <Methods>
+            <Method cyclomaticComplexity="2">
+              <Name>System.Int32 Test.Calculator::Sign(System.Int32)</Name>
+              <FileRef uid="1" />
+              <SequencePoints>
+                <SequencePoint vc="3" sl="10" el="10" fileid="1" />
+                <SequencePoint vc="2" sl="11" el="12" fileid="1" />
+                <SequencePoint vc="0" sl="14" el="14" fileid="1" />
+              </SequencePoints>
+              <BranchPoints>
+                <BranchPoint vc="2" uspid="8" path="0" offset="2" offsetend="4" sl="10" fileid="1" />
+                <BranchPoint vc="0" uspid="8" path="1" offset="2" offsetend="6" sl="10" fileid="1" />
+              </BranchPoints>
+            </Method>
+          </Methods>
+        </Class>
+      </Classes>
+    </Module>
+  </Modules>
+</CoverageSession>`,
+			sourceFiles: map[string]string{
+				reportedPath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.UnresolvedSourceFiles)
+				assert.Equal(t, "OpenCover", result.ParserName)
+
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+				assert.Equal(t, reportedPath, fileCov.Path)
+				require.Len(t, fileCov.Lines, 4, "Multi-line sequence points should expand to every line")
+
+				assert.Equal(t, 3, fileCov.Lines[10].Hits)
+				assert.Equal(t, 2, fileCov.Lines[11].Hits)
+				assert.Equal(t, 2, fileCov.Lines[12].Hits)
+				assert.Equal(t, 0, fileCov.Lines[14].Hits)
+
+				assert.Equal(t, 2, fileCov.Lines[10].TotalBranches)
+				assert.Equal(t, 1, fileCov.Lines[10].CoveredBranches)
+				assert.Zero(t, fileCov.Lines[11].TotalBranches)
+
+				require.Len(t, fileCov.Methods, 1)
+				method := fileCov.Methods[0]
+				assert.Equal(t, "System.Int32 Test.Calculator::Sign(System.Int32)", method.Name)
+				assert.Equal(t, 10, method.StartLine)
+				assert.Equal(t, 14, method.EndLine)
+				require.NotNil(t, method.CyclomaticComplexity)
+				assert.Equal(t, 2, *method.CyclomaticComplexity)
+			},
+		},
+		{
+			name: "Duplicated branch points and skipped methods",
+			reportContent: `<?xml version="1.0" encoding="utf-8"?>
+<CoverageSession>
+  <Modules>
+    <Module hash="ABC">
+      <Files>
+        <File uid="1" fullPath="/app/src/Test/Calculator.cs" />
+      </Files>
+      <Classes>
+        <Class>
+          <Methods>
+            <Method>
+              <FileRef uid="1" />
+              <SequencePoints>
+                <SequencePoint vc="1" sl="5" el="5" />
+              </SequencePoints>
+              <BranchPoints>
+                <BranchPoint vc="0" uspid="1" path="0" offset="1" offsetend="2" sl="5" />
+                <BranchPoint vc="1" uspid="1" path="1" offset="1" offsetend="3" sl="5" />
+              </BranchPoints>
+            </Method>
+            <Method>
+              <FileRef uid="1" />
+              <SequencePoints>
+                <SequencePoint vc="4" sl="5" el="5" />
+              </SequencePoints>
+              <BranchPoints>
+                <BranchPoint vc="1" uspid="7" path="0" offset="1" offsetend="2" sl="5" />
+              </BranchPoints>
+            </Method>
+            <Method skippedDueTo="Filter">
+              <FileRef uid="1" />
+              <SequencePoints>
+                <SequencePoint vc="9" sl="20" el="20" />
+              </SequencePoints>
+            </Method>
+          </Methods>
+        </Class>
+      </Classes>
+    </Module>
+  </Modules>
+</CoverageSession>`,
+			sourceFiles: map[string]string{
+				reportedPath: `// Dummy content`,
+			},
+			sourceDirs: []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, result.FileCoverage, 1)
+				fileCov := result.FileCoverage[0]
+
+				require.Len(t, fileCov.Lines, 1, "Skipped methods must not contribute lines")
+				assert.Equal(t, 4, fileCov.Lines[5].Hits, "The most visited sequence point wins")
+				assert.Equal(t, 2, fileCov.Lines[5].TotalBranches, "Branch points at the same offset are counted once, whatever their uspid")
+				assert.Equal(t, 2, fileCov.Lines[5].CoveredBranches)
+			},
+		},
+		{
+			name: "Source File Not Found - Should report as unresolved",
+			reportContent: `<?xml version="1.0" encoding="utf-8"?>
+<CoverageSession>
+  <Modules>
+    <Module>
+      <Files>
+        <File uid="1" fullPath="C:\build\Test\DoesNotExist.cs" />
+      </Files>
+      <Classes>
+        <Class>
+          <Methods>
+            <Method>
+              <FileRef uid="1" />
+              <SequencePoints>
+                <SequencePoint vc="1" sl="5" el="5" fileid="1" />
+              </SequencePoints>
+            </Method>
+          </Methods>
+        </Class>
+      </Classes>
+    </Module>
+  </Modules>
+</CoverageSession>`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+
+				require.Len(t, result.FileCoverage, 1)
+				require.Len(t, result.UnresolvedSourceFiles, 1)
+				assert.Equal(t, result.FileCoverage[0].Path, result.UnresolvedSourceFiles[0])
+			},
+		},
+		{
+			name: "Report is logically empty (no modules)",
+			reportContent: `<?xml version="1.0" encoding="utf-8"?>
+<CoverageSession>
+  <Modules />
+</CoverageSession>`,
+			sourceFiles: map[string]string{},
+			sourceDirs:  []string{sourceDir},
+			asserter: func(t *testing.T, result *parsers.ParserResult, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, result)
+				assert.Empty(t, result.FileCoverage, "Should produce no file coverage for an empty report")
+				assert.Empty(t, result.UnresolvedSourceFiles)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tmpDir := t.TempDir()
+			reportPath := filepath.Join(tmpDir, reportFileName)
+			err := os.WriteFile(reportPath, []byte(tc.reportContent), 0644)
+			require.NoError(t, err)
+
+			mockFS := testutil.NewMockFilesystem("unix")
+			for path, content := range tc.sourceFiles {
+				mockFS.AddFile(path, content)
+			}
+
+			mockConfig := testutil.NewTestConfig(tc.sourceDirs)
+			parser := parser_opencover.NewOpenCoverParser(utils.NewSourceResolver(mockFS, nil))
+
+			// Act
+			result, err := parser.Parse(reportPath, mockConfig)
+
+			// Assert
+			tc.asserter(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package parser_opencover
+
+import (
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// processingOrchestrator is responsible for converting the raw OpenCover XML
+// data into a flat list of per-file coverage metrics.
+type processingOrchestrator struct {
+	resolver *utils.SourceResolver
+	config   parsers.ParserConfig
+	logger   *slog.Logger
+}
+
+// fileAccumulator collects the raw data of a single source file while the
+// report is walked. Branches are kept by their path and IL offset, so that
+// branch points the compiler duplicates across generated methods or classes
+// are only counted once.
+type fileAccumulator struct {
+	lines    map[int]model.LineMetrics
+	branches map[int]map[string]bool // line -> branch identity -> visited
+	methods  []model.MethodMetrics
+}
+
+func newProcessingOrchestrator(resolver *utils.SourceResolver, config parsers.ParserConfig, logger *slog.Logger) *processingOrchestrator {
+	return &processingOrchestrator{
+		resolver: resolver,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// processModules is the main entry point for the orchestrator. OpenCover groups
+// coverage by assembly (module) and class rather than by file, so the sequence
+// and branch points of every method are redistributed to the file they point at.
+func (o *processingOrchestrator) processModules(modules []ModuleXML) ([]parsers.FileCoverage, []string) {
+	files := make(map[string]*fileAccumulator)
+
+	for _, moduleXML := range modules {
+		if moduleXML.SkippedDueTo != "" {
+			o.logger.Debug("Skipping module", "module", moduleXML.ModuleName, "reason", moduleXML.SkippedDueTo)
+			continue
+		}
+
+		// File uids are only unique within the module that declares them.
+		filesByUID := make(map[string]string, len(moduleXML.Files.File))
+		for _, fileXML := range moduleXML.Files.File {
+			filesByUID[fileXML.UID] = filepath.ToSlash(fileXML.FullPath)
+		}
+
+		for _, classXML := range moduleXML.Classes.Class {
+			if classXML.SkippedDueTo != "" {
+				continue
+			}
+			for _, methodXML := range classXML.Methods.Method {
+				if methodXML.SkippedDueTo != "" {
+					continue
+				}
+				o.processMethod(methodXML, filesByUID, files)
+			}
+		}
+	}
+
+	sortedPaths := make([]string, 0, len(files))
+	for path := range files {
+		sortedPaths = append(sortedPaths, path)
+	}
+	sort.Strings(sortedPaths)
+
+	sourceDir := ""
+	if len(o.config.SourceDirectories()) > 0 {
+		sourceDir = o.config.SourceDirectories()[0]
+	}
+
+	var finalFileCoverage []parsers.FileCoverage
+	var unresolvedFiles []string
+	for _, reportPath := range sortedPaths {
+		path := parsers.MapReportPath(o.config, reportPath)
+		if _, err := o.resolver.Resolve(path, []string{sourceDir}); err != nil {
+			o.logger.Warn("Source file not found, it will be marked as unresolved.", "file", path, "error", err)
+			unresolvedFiles = append(unresolvedFiles, path)
+		}
+
+		acc := files[reportPath]
+		sort.SliceStable(acc.methods, func(i, j int) bool {
+			return acc.methods[i].StartLine < acc.methods[j].StartLine
+		})
+		finalFileCoverage = append(finalFileCoverage, parsers.FileCoverage{
+			Path:    path,
+			Lines:   acc.finalize(),
+			Methods: acc.methods,
+		})
+	}
+
+	return finalFileCoverage, unresolvedFiles
+}
+
+// processMethod merges the sequence points and branch points of a single
+// method into the accumulators of the files they belong to, and adds the
+// method to the file it is declared in.
+func (o *processingOrchestrator) processMethod(methodXML MethodXML, filesByUID map[string]string, files map[string]*fileAccumulator) {
+	accumulatorFor := func(fileID string) *fileAccumulator {
+		if fileID == "" {
+			fileID = methodXML.FileRef.UID
+		}
+		path, ok := filesByUID[fileID]
+		if !ok || path == "" {
+			return nil
+		}
+		acc, ok := files[path]
+		if !ok {
+			acc = &fileAccumulator{
+				lines:    make(map[int]model.LineMetrics),
+				branches: make(map[int]map[string]bool),
+			}
+			files[path] = acc
+		}
+		return acc
+	}
+
+	methodStart, methodEnd := 0, 0
+	for _, sp := range methodXML.SequencePoints.SequencePoint {
+		startLine := utils.ParseInt(sp.StartLine, 0)
+		if startLine <= 0 {
+			continue
+		}
+		endLine := utils.ParseInt(sp.EndLine, startLine)
+		if endLine < startLine {
+			endLine = startLine
+		}
+		visits := utils.ParseInt(sp.VisitCount, 0)
+
+		acc := accumulatorFor(sp.FileID)
+		if acc == nil {
+			continue
+		}
+		if sp.FileID == "" || sp.FileID == methodXML.FileRef.UID {
+			if methodStart == 0 || startLine < methodStart {
+				methodStart = startLine
+			}
+			methodEnd = max(methodEnd, endLine)
+		}
+
+		// A sequence point can span several lines; each of them shares its
+		// visit count. When sequence points overlap on a line, the most
+		// visited one is the most representative for that line.
+		for l := startLine; l <= endLine; l++ {
+			if existing, ok := acc.lines[l]; !ok || visits > existing.Hits {
+				existing.Hits = visits
+				acc.lines[l] = existing
+			}
+		}
+	}
+
+	for _, bp := range methodXML.BranchPoints.BranchPoint {
+		line := utils.ParseInt(bp.StartLine, 0)
+		if line <= 0 {
+			continue
+		}
+
+		acc := accumulatorFor(bp.FileID)
+		if acc == nil {
+			continue
+		}
+
+		if _, ok := acc.branches[line]; !ok {
+			acc.branches[line] = make(map[string]bool)
+		}
+		identity := bp.Path + "_" + bp.Offset
+		visited := utils.ParseInt(bp.VisitCount, 0)
+		acc.branches[line][identity] = acc.branches[line][identity] || visited > 0
+	}
+
+	// The bounds of a method are those of its sequence points in the file it
+	// is declared in. Methods without any (e.g. abstract ones) are skipped.
+	if methodStart == 0 || methodXML.Name == "" {
+		return
+	}
+	if acc := accumulatorFor(""); acc != nil {
+		method := model.MethodMetrics{
+			Name:      methodXML.Name,
+			StartLine: methodStart,
+			EndLine:   methodEnd,
+		}
+		if complexity := utils.ParseInt(methodXML.CyclomaticComplexity, 0); complexity > 0 {
+			method.CyclomaticComplexity = &complexity
+		}
+		acc.methods = append(acc.methods, method)
+	}
+}
+
+// finalize attaches the collected branch counts to their lines and returns
+// the resulting line metrics. Branch points are only reported on lines that
+// also carry a sequence point, since only those are coverable.
+func (acc *fileAccumulator) finalize() map[int]model.LineMetrics {
+	for line, branches := range acc.branches {
+		metric, ok := acc.lines[line]
+		if !ok {
+			continue
+		}
+		metric.TotalBranches = len(branches)
+		metric.CoveredBranches = 0
+		for _, visited := range branches {
+			if visited {
+				metric.CoveredBranches++
+			}
+		}
+		acc.lines[line] = metric
+	}
+	return acc.lines
+}
@@ -0,0 +1,83 @@
+package patch
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+)
+
+// Compute intersects the changed lines with the line coverage of the tree.
+// base describes what the diff was taken against and is only recorded.
+//
+// Diff paths are relative to the repository root and tree paths to the
+// project root, which may differ. A changed file is therefore matched to the
+// tree file whose path equals it, or ends with it or is its ending on a
+// segment boundary. When several files match equally well, the change is
+// treated as outside the reports rather than attributed to the wrong file.
+func Compute(tree *model.SummaryTree, changes []FileChange, base string) *model.PatchCoverage {
+	files := tree.FilesByPath()
+
+	patch := &model.PatchCoverage{Base: base}
+	for _, change := range changes {
+		fileNode := matchFile(change.Path, files)
+
+		filePatch := model.FilePatchCoverage{Path: change.Path}
+		filePatch.Metrics.LinesChanged = len(change.Lines)
+		if fileNode != nil {
+			filePatch.Path = fileNode.Path
+			filePatch.InReport = true
+			for _, lineNumber := range change.Lines {
+				line, ok := fileNode.Lines[lineNumber]
+				if !ok || line.Hits < 0 {
+					continue // Not coverable, e.g. a comment or a blank line.
+				}
+				filePatch.Metrics.LinesValid++
+				if line.Hits > 0 {
+					filePatch.Metrics.LinesCovered++
+				} else {
+					filePatch.UncoveredLines = append(filePatch.UncoveredLines, lineNumber)
+				}
+				filePatch.Metrics.BranchesValid += line.TotalBranches
+				filePatch.Metrics.BranchesCovered += line.CoveredBranches
+			}
+		}
+
+		patch.Metrics.Add(filePatch.Metrics)
+		patch.Files = append(patch.Files, filePatch)
+	}
+
+	sort.Slice(patch.Files, func(i, j int) bool { return patch.Files[i].Path < patch.Files[j].Path })
+	return patch
+}
+
+// matchFile finds the tree file a diff path refers to.
+func matchFile(diffPath string, files map[string]*model.FileNode) *model.FileNode {
+	if fileNode, ok := files[diffPath]; ok {
+		return fileNode
+	}
+
+	var best *model.FileNode
+	bestLength, tie := 0, false
+	for path, fileNode := range files {
+		var length int
+		switch {
+		case strings.HasSuffix(diffPath, "/"+path):
+			length = len(path)
+		case strings.HasSuffix(path, "/"+diffPath):
+			length = len(diffPath)
+		default:
+			continue
+		}
+		switch {
+		case length > bestLength:
+			best, bestLength, tie = fileNode, length, false
+		case length == bestLength:
+			tie = true
+		}
+	}
+	if tie {
+		return nil
+	}
+	return best
+}
@@ -0,0 +1,61 @@
+package patch_test
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/patch"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	// Arrange
+	tree := testutil.NewTree(&model.FileNode{
+		Name: "app.go",
+		Path: "src/app.go",
+		Lines: map[int]model.LineMetrics{
+			10: {Hits: 3, TotalBranches: 2, CoveredBranches: 1},
+			11: {Hits: 0},
+			12: {Hits: -1},
+			13: {Hits: 1},
+		},
+	})
+
+	changes := []patch.FileChange{
+		// The repository root is above the project root.
+		{Path: "service/src/app.go", Lines: []int{10, 11, 12, 20}},
+		{Path: "README.md", Lines: []int{1, 2}},
+	}
+
+	// Act
+	result := patch.Compute(tree, changes, "origin/main...HEAD")
+
+	// Assert
+	assert.Equal(t, "origin/main...HEAD", result.Base)
+	assert.Equal(t, model.PatchMetrics{LinesChanged: 6, LinesValid: 2, LinesCovered: 1, BranchesValid: 2, BranchesCovered: 1}, result.Metrics)
+
+	require.Len(t, result.Files, 2)
+	readme := result.Files[0]
+	assert.Equal(t, "README.md", readme.Path)
+	assert.False(t, readme.InReport)
+	assert.Equal(t, 0, readme.Metrics.LinesValid)
+
+	app := result.Files[1]
+	assert.Equal(t, "src/app.go", app.Path)
+	assert.True(t, app.InReport)
+	assert.Equal(t, []int{11}, app.UncoveredLines)
+}
+
+func TestCompute_AmbiguousPathIsNotAttributed(t *testing.T) {
+	// Arrange
+	tree := testutil.NewTree(testutil.NewFile("api/handler.go", 1), testutil.NewFile("web/handler.go", 1))
+
+	// Act
+	result := patch.Compute(tree, []patch.FileChange{{Path: "handler.go", Lines: []int{1}}}, "diff.patch")
+
+	// Assert
+	require.Len(t, result.Files, 1)
+	assert.False(t, result.Files[0].InReport)
+}
@@ -0,0 +1,137 @@
+// Package patch computes the coverage of the lines a change adds or modifies,
+// from a unified diff and the line coverage of a summary tree.
+package patch
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileChange lists the lines of a file that a diff adds or changes.
+type FileChange struct {
+	Path  string // Path of the file after the change, using forward slashes.
+	Lines []int  // Line numbers in the changed file, in ascending order.
+}
+
+// hunkHeader matches "@@ -12,7 +12,9 @@", where the counts are optional.
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// ParseUnifiedDiff reads a unified diff, as produced by "git diff" or
+// "diff -u", and returns the added lines of every file, ordered by path.
+// Removed lines are ignored, since they have no coverage, and so are files
+// that the diff deletes or only removes lines from.
+func ParseUnifiedDiff(r io.Reader) ([]FileChange, error) {
+	changes := make(map[string][]int)
+	var currentPath string
+	// Git prefixes the paths with "a/" and "b/", unless run with --no-prefix.
+	// Paths of other tools are taken as they are, even if they start with "b/".
+	var gitPrefixes bool
+	var newLine, oldRemaining, newRemaining int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+
+		// Inside a hunk, the counts of its header tell where it ends, so that
+		// removed lines starting with "--" are not taken for file headers.
+		if oldRemaining > 0 || newRemaining > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if currentPath != "" {
+					changes[currentPath] = append(changes[currentPath], newLine)
+				}
+				newLine++
+				newRemaining--
+			case strings.HasPrefix(line, "-"):
+				oldRemaining--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+			default:
+				// A context line, which some tools write without the leading space when empty.
+				newLine++
+				oldRemaining--
+				newRemaining--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			gitPrefixes = strings.HasPrefix(line, "diff --git a/")
+		case strings.HasPrefix(line, "--- "):
+			gitPrefixes = gitPrefixes || strings.HasPrefix(headerPath(strings.TrimPrefix(line, "--- ")), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			currentPath = diffPath(strings.TrimPrefix(line, "+++ "), gitPrefixes)
+			gitPrefixes = false
+		case strings.HasPrefix(line, "@@"):
+			match := hunkHeader.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("line %d: malformed hunk header %q", lineNumber, line)
+			}
+			newLine, _ = strconv.Atoi(match[3])
+			oldRemaining = hunkCount(match[2])
+			newRemaining = hunkCount(match[4])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read diff: %w", err)
+	}
+
+	paths := make([]string, 0, len(changes))
+	for path := range changes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	result := make([]FileChange, 0, len(paths))
+	for _, path := range paths {
+		lines := changes[path]
+		sort.Ints(lines)
+		result = append(result, FileChange{Path: path, Lines: lines})
+	}
+	return result, nil
+}
+
+// diffPath extracts the file path of a "+++" header, without the "b/" that
+// git prefixes it with when gitPrefixes is set. It returns "" for deleted
+// files.
+func diffPath(header string, gitPrefixes bool) string {
+	path := headerPath(header)
+	if path == "/dev/null" {
+		return ""
+	}
+	if gitPrefixes {
+		path = strings.TrimPrefix(path, "b/")
+	}
+	return strings.ReplaceAll(path, `\`, "/")
+}
+
+// headerPath extracts the path of a "---" or "+++" header as written.
+func headerPath(header string) string {
+	// "diff -u" appends a tab and a timestamp to the path.
+	if tab := strings.IndexByte(header, '\t'); tab >= 0 {
+		header = header[:tab]
+	}
+	header = strings.TrimSpace(header)
+	if unquoted, err := strconv.Unquote(header); err == nil {
+		header = unquoted
+	}
+	return header
+}
+
+// hunkCount parses the line count of a hunk header, which is 1 when omitted.
+func hunkCount(count string) int {
+	if count == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(count)
+	return n
+}
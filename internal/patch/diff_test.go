@@ -0,0 +1,123 @@
+package patch_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnifiedDiff(t *testing.T) {
+	testCases := []struct {
+		name        string
+		diff        string
+		expected    []patch.FileChange
+		expectError bool
+	}{
+		{
+			name: "Git diff with context, additions and removals",
+			diff: `diff --git a/src/app.go b/src/app.go
+index 3b18e51..a9c2f4d 100644
+--- a/src/app.go
++++ b/src/app.go
+@@ -10,6 +10,8 @@ func main() {
+ 	a := 1
+-	b := 2
++	b := 3
++	c := 4
+ 	fmt.Println(a)
++	fmt.Println(b, c)
+ 	return
+ }
+`,
+			expected: []patch.FileChange{{Path: "src/app.go", Lines: []int{11, 12, 14}}},
+		},
+		{
+			name: "Zero context hunks, new and deleted files",
+			diff: `diff --git a/b.go b/b.go
+--- a/b.go
++++ b/b.go
+@@ -3 +3 @@
+-old
++new
+@@ -20,0 +21,2 @@
++added one
++added two
+diff --git a/gone.go b/gone.go
+deleted file mode 100644
+--- a/gone.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package gone
+-
+diff --git a/a.go b/a.go
+new file mode 100644
+--- /dev/null
++++ b/a.go
+@@ -0,0 +1,2 @@
++package a
++
+`,
+			expected: []patch.FileChange{
+				{Path: "a.go", Lines: []int{1, 2}},
+				{Path: "b.go", Lines: []int{3, 21, 22}},
+			},
+		},
+		{
+			name: "Removed line that looks like a file header",
+			diff: `--- a/sql/schema.sql
++++ b/sql/schema.sql
+@@ -1,2 +1,2 @@
+--- old comment
++-- new comment
+ SELECT 1;
+`,
+			expected: []patch.FileChange{{Path: "sql/schema.sql", Lines: []int{1}}},
+		},
+		{
+			name:     "Plain diff -u with timestamps and CRLF",
+			diff:     "--- old/main.c\t2024-01-01 10:00:00\r\n+++ new/main.c\t2024-01-02 10:00:00\r\n@@ -1 +1,2 @@\r\n int x;\r\n+int y;\r\n",
+			expected: []patch.FileChange{{Path: "new/main.c", Lines: []int{2}}},
+		},
+		{
+			name:     "Plain diff -u of a directory named b",
+			diff:     "--- b/main.c.orig\t2024-01-01 10:00:00\n+++ b/main.c\t2024-01-02 10:00:00\n@@ -1 +1,2 @@\n int x;\n+int y;\n",
+			expected: []patch.FileChange{{Path: "b/main.c", Lines: []int{2}}},
+		},
+		{
+			name: "Git diff without prefixes",
+			diff: `diff --git b/util.go b/util.go
+--- b/util.go
++++ b/util.go
+@@ -1 +1,2 @@
+ package b
++var x = 1
+`,
+			expected: []patch.FileChange{{Path: "b/util.go", Lines: []int{2}}},
+		},
+		{
+			name: "Malformed hunk header",
+			diff: `+++ b/a.go
+@@ broken @@
+`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			changes, err := patch.ParseUnifiedDiff(strings.NewReader(tc.diff))
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, changes)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package patch
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GitDiff returns the changes of HEAD since it diverged from base, as shown
+// by "git diff base...HEAD" in the repository containing dir. Only the
+// changed lines are requested, since context lines do not count as changed.
+//
+// The options that shape the output are passed explicitly, so the user's git
+// configuration cannot change it: the paths always carry the "a/" and "b/"
+// prefixes ParseUnifiedDiff strips, whatever diff.noprefix or
+// diff.mnemonicPrefix say, and renames are always detected, whatever
+// diff.renames says. A renamed file then only counts the lines that changed,
+// rather than every line as if it were new.
+func GitDiff(dir, base string) ([]byte, error) {
+	cmd := exec.Command("git", "diff", "--no-color", "--no-ext-diff", "--unified=0",
+		"--src-prefix=a/", "--dst-prefix=b/", "--find-renames", base+"...HEAD")
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff %s...HEAD failed: %w: %s", base, err, strings.TrimSpace(stderr.String()))
+	}
+	return output, nil
+}
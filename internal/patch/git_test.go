@@ -0,0 +1,72 @@
+package patch_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitDiff_IgnoresUserDiffConfig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	// Arrange
+	// The same as running every git command with "-c diff.noprefix=true -c diff.renames=false".
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_COUNT", "2")
+	t.Setenv("GIT_CONFIG_KEY_0", "diff.noprefix")
+	t.Setenv("GIT_CONFIG_VALUE_0", "true")
+	t.Setenv("GIT_CONFIG_KEY_1", "diff.renames")
+	t.Setenv("GIT_CONFIG_VALUE_1", "false")
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	writeFile := func(path string, lines ...string) {
+		t.Helper()
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	}
+
+	git("init", "--quiet", "--initial-branch=main")
+	writeFile("src/app.go", "package src", "", "func a() {}")
+	writeFile("src/old.go", "package src", "", "func b() {}", "", "func c() {}", "", "func d() {}")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "base")
+
+	git("checkout", "--quiet", "-b", "feature")
+	writeFile("src/app.go", "package src", "", "func a() {}", "", "func e() {}")
+	require.NoError(t, os.Remove(filepath.Join(dir, "src", "old.go")))
+	writeFile("src/new.go", "package src", "", "func b() {}", "", "func c() {}", "", "func d() {}", "", "func f() {}")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "change")
+
+	// Act
+	diff, err := patch.GitDiff(dir, "main")
+	require.NoError(t, err)
+	changes, err := patch.ParseUnifiedDiff(strings.NewReader(string(diff)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []patch.FileChange{
+		{Path: "src/app.go", Lines: []int{4, 5}},
+		{Path: "src/new.go", Lines: []int{8, 9}},
+	}, changes, "Paths keep their prefixes stripped and the renamed file only counts its new lines")
+}
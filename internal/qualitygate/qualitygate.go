@@ -0,0 +1,233 @@
+// Package qualitygate checks the coverage of a summary tree against minimum
+// thresholds, so that a run can fail a CI build when coverage drops.
+//
+// Global thresholds are checked against the metrics of the whole project.
+// Path rules add thresholds for the directories and files whose
+// project-relative path matches a pattern, using the same wildcard syntax as
+// the file filters:
+//
+//	path_thresholds:
+//	  - path: "internal/parsers/**"
+//	    line: 90
+//	  - path: "demo_projects/**"
+//	    ignore: true
+//
+// A pattern ending in "/**" matches the directory itself as well as all the
+// directories and files below it. Every directory and file is checked against
+// the first rule that matches it. A rule with "ignore" exempts the matching
+// paths from all later rules, and leaves them out of the project metrics that
+// the global thresholds are checked against.
+package qualitygate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/filtering"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// Thresholds are the minimum coverage percentages (0-100). A zero threshold
+// is not checked.
+type Thresholds struct {
+	LineCoverage   float64
+	BranchCoverage float64
+	MethodCoverage float64
+}
+
+// Evaluate checks the metrics of the whole project against every threshold
+// that is set. The results are in a fixed order: line, branch, then method
+// coverage.
+func Evaluate(metrics model.CoverageMetrics, thresholds Thresholds) []model.GateResult {
+	return evaluate("", metrics, thresholds)
+}
+
+func evaluate(path string, metrics model.CoverageMetrics, thresholds Thresholds) []model.GateResult {
+	var results []model.GateResult
+	check := func(metric model.GateMetric, minimum float64, covered, valid int) {
+		if minimum <= 0 {
+			return
+		}
+		// Truncating to two decimals matches what the result prints, so a
+		// gate never fails with an actual value shown equal to the minimum.
+		actual := utils.CalculatePercentage(covered, valid, 2)
+		results = append(results, model.GateResult{
+			Path:    path,
+			Metric:  metric,
+			Minimum: minimum,
+			Actual:  actual,
+			Passed:  math.IsNaN(actual) || actual >= minimum,
+		})
+	}
+
+	check(model.GateLineCoverage, thresholds.LineCoverage, metrics.LinesCovered, metrics.LinesValid)
+	check(model.GateBranchCoverage, thresholds.BranchCoverage, metrics.BranchesCovered, metrics.BranchesValid)
+	check(model.GateMethodCoverage, thresholds.MethodCoverage, metrics.MethodsCovered, metrics.MethodsValid)
+	return results
+}
+
+// Failed returns the results of the gates that did not pass.
+func Failed(results []model.GateResult) []model.GateResult {
+	var failed []model.GateResult
+	for _, result := range results {
+		if !result.Passed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// FailedError is returned when at least one quality gate did not pass.
+type FailedError struct {
+	Failures []model.GateResult
+}
+
+func (e *FailedError) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		messages = append(messages, failure.String())
+	}
+	return fmt.Sprintf("%d quality gate(s) failed: %s", len(e.Failures), strings.Join(messages, "; "))
+}
+
+// PathRule is a per-path threshold rule as written in the configuration file.
+type PathRule struct {
+	Path   string  `yaml:"path"`
+	Line   float64 `yaml:"line"`
+	Branch float64 `yaml:"branch"`
+	Method float64 `yaml:"method"`
+	Ignore bool    `yaml:"ignore"`
+}
+
+// PathRules applies an ordered list of path rules to a summary tree.
+type PathRules struct {
+	rules []compiledPathRule
+}
+
+type compiledPathRule struct {
+	matcher    filtering.IFilter
+	thresholds Thresholds
+	ignore     bool
+}
+
+// NewPathRules validates and compiles the given rules.
+func NewPathRules(rules []PathRule) (*PathRules, error) {
+	pathRules := &PathRules{}
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.Path) == "" {
+			return nil, fmt.Errorf("path threshold %d: 'path' must not be empty", i+1)
+		}
+		for _, minimum := range []float64{rule.Line, rule.Branch, rule.Method} {
+			if minimum < 0 || minimum > 100 {
+				return nil, fmt.Errorf("path threshold %d ('%s'): minimums must be between 0 and 100, got %g", i+1, rule.Path, minimum)
+			}
+		}
+
+		// "dir/**" also matches the directory itself, so that it covers the
+		// directory's own totals along with everything below it.
+		pattern := strings.TrimSpace(rule.Path)
+		patterns := []string{"+" + pattern}
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok && dir != "" {
+			patterns = append(patterns, "+"+dir)
+		}
+		matcher, err := filtering.NewDefaultFilter(patterns, true)
+		if err != nil {
+			return nil, fmt.Errorf("path threshold %d: %w", i+1, err)
+		}
+		pathRules.rules = append(pathRules.rules, compiledPathRule{
+			matcher:    matcher,
+			thresholds: Thresholds{LineCoverage: rule.Line, BranchCoverage: rule.Branch, MethodCoverage: rule.Method},
+			ignore:     rule.Ignore,
+		})
+	}
+	return pathRules, nil
+}
+
+// EvaluateTree checks every directory and file below root against the first
+// rule matching its path. The results are ordered by path.
+func (r *PathRules) EvaluateTree(root *model.DirNode) []model.GateResult {
+	if r == nil || len(r.rules) == 0 || root == nil {
+		return nil
+	}
+
+	var results []model.GateResult
+	var walk func(dir *model.DirNode)
+	walk = func(dir *model.DirNode) {
+		for _, subdir := range dir.Subdirs {
+			results = append(results, r.evaluateNode(subdir.Path, subdir.Metrics)...)
+			walk(subdir)
+		}
+		for _, file := range dir.Files {
+			results = append(results, r.evaluateNode(file.Path, file.Metrics)...)
+		}
+	}
+	walk(root)
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+	return results
+}
+
+// ExcludeIgnored returns the project metrics less those of the directories
+// and files that a rule ignores, for checking the global thresholds. An
+// ignored directory is subtracted as a whole, so nothing below it is counted
+// twice.
+func (r *PathRules) ExcludeIgnored(metrics model.CoverageMetrics, root *model.DirNode) model.CoverageMetrics {
+	if r == nil || len(r.rules) == 0 || root == nil {
+		return metrics
+	}
+
+	subtract := func(ignored model.CoverageMetrics) {
+		metrics.LinesCovered -= ignored.LinesCovered
+		metrics.LinesValid -= ignored.LinesValid
+		metrics.BranchesCovered -= ignored.BranchesCovered
+		metrics.BranchesValid -= ignored.BranchesValid
+		metrics.TotalLines -= ignored.TotalLines
+		metrics.MethodsCovered -= ignored.MethodsCovered
+		metrics.MethodsFullyCovered -= ignored.MethodsFullyCovered
+		metrics.MethodsValid -= ignored.MethodsValid
+	}
+
+	var walk func(dir *model.DirNode)
+	walk = func(dir *model.DirNode) {
+		for _, subdir := range dir.Subdirs {
+			if r.isIgnored(subdir.Path) {
+				subtract(subdir.Metrics)
+				continue
+			}
+			walk(subdir)
+		}
+		for _, file := range dir.Files {
+			if r.isIgnored(file.Path) {
+				subtract(file.Metrics)
+			}
+		}
+	}
+	walk(root)
+	return metrics
+}
+
+// isIgnored tells whether the first rule matching path is an "ignore" rule.
+func (r *PathRules) isIgnored(path string) bool {
+	for _, rule := range r.rules {
+		if rule.matcher.IsElementIncludedInReport(path) {
+			return rule.ignore
+		}
+	}
+	return false
+}
+
+func (r *PathRules) evaluateNode(path string, metrics model.CoverageMetrics) []model.GateResult {
+	for _, rule := range r.rules {
+		if !rule.matcher.IsElementIncludedInReport(path) {
+			continue
+		}
+		if rule.ignore {
+			return nil
+		}
+		return evaluate(path, metrics, rule.thresholds)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package qualitygate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/qualitygate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	metrics := model.CoverageMetrics{
+		LinesCovered:   799,
+		LinesValid:     1000,
+		MethodsCovered: 9,
+		MethodsValid:   10,
+	}
+
+	testCases := []struct {
+		name           string
+		thresholds     qualitygate.Thresholds
+		expectedPassed []bool
+		expectedText   []string
+	}{
+		{
+			name:       "No thresholds checks nothing",
+			thresholds: qualitygate.Thresholds{},
+		},
+		{
+			name:           "Coverage just below the minimum fails",
+			thresholds:     qualitygate.Thresholds{LineCoverage: 80},
+			expectedPassed: []bool{false},
+			expectedText:   []string{"Line coverage: 79.9% is below the minimum of 80%"},
+		},
+		{
+			name:           "Coverage equal to the minimum passes",
+			thresholds:     qualitygate.Thresholds{LineCoverage: 79.9, MethodCoverage: 90},
+			expectedPassed: []bool{true, true},
+			expectedText: []string{
+				"Line coverage: 79.9% meets the minimum of 79.9%",
+				"Method coverage: 90% meets the minimum of 90%",
+			},
+		},
+		{
+			name:           "Gate on a metric without data passes",
+			thresholds:     qualitygate.Thresholds{BranchCoverage: 50},
+			expectedPassed: []bool{true},
+			expectedText:   []string{"Branch coverage: not measured by the reports, minimum of 50% ignored"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			results := qualitygate.Evaluate(metrics, tc.thresholds)
+
+			// Assert
+			require.Len(t, results, len(tc.expectedPassed))
+			for i, result := range results {
+				assert.Equal(t, tc.expectedPassed[i], result.Passed)
+				assert.Equal(t, tc.expectedText[i], result.String())
+			}
+		})
+	}
+}
+
+func TestFailedError(t *testing.T) {
+	results := qualitygate.Evaluate(
+		model.CoverageMetrics{LinesCovered: 1, LinesValid: 4, BranchesCovered: 3, BranchesValid: 4},
+		qualitygate.Thresholds{LineCoverage: 50, BranchCoverage: 50},
+	)
+
+	failures := qualitygate.Failed(results)
+	require.Len(t, failures, 1)
+
+	var err error = &qualitygate.FailedError{Failures: failures}
+	var gateErr *qualitygate.FailedError
+	require.True(t, errors.As(err, &gateErr))
+	assert.Equal(t, "1 quality gate(s) failed: Line coverage: 25% is below the minimum of 50%", err.Error())
+}
+
+func TestPathRules_EvaluateTree(t *testing.T) {
+	// Arrange
+	root := &model.DirNode{Path: ".", Subdirs: map[string]*model.DirNode{}, Files: map[string]*model.FileNode{}}
+	addDir := func(parent *model.DirNode, name, path string, covered, valid int) *model.DirNode {
+		dir := &model.DirNode{
+			Name:    name,
+			Path:    path,
+			Metrics: model.CoverageMetrics{LinesCovered: covered, LinesValid: valid},
+			Subdirs: map[string]*model.DirNode{},
+			Files:   map[string]*model.FileNode{},
+			Parent:  parent,
+		}
+		parent.Subdirs[name] = dir
+		return dir
+	}
+	addFile := func(dir *model.DirNode, name string, covered, valid int) {
+		dir.Files[name] = &model.FileNode{
+			Name:    name,
+			Path:    dir.Path + "/" + name,
+			Metrics: model.CoverageMetrics{LinesCovered: covered, LinesValid: valid},
+			Parent:  dir,
+		}
+	}
+
+	internal := addDir(root, "internal", "internal", 19, 20)
+	parsers := addDir(internal, "parsers", "internal/parsers", 17, 20)
+	addFile(parsers, "good.go", 10, 10)
+	addFile(parsers, "bad.go", 7, 10)
+	demo := addDir(root, "demo_projects", "demo_projects", 0, 10)
+	addFile(demo, "main.go", 0, 10)
+
+	rules, err := qualitygate.NewPathRules([]qualitygate.PathRule{
+		{Path: "demo_projects/**", Ignore: true},
+		{Path: "internal/parsers/**", Line: 90},
+		{Path: "*", Line: 50},
+	})
+	require.NoError(t, err)
+
+	// Act
+	results := rules.EvaluateTree(root)
+
+	// Assert
+	var summaries []string
+	for _, result := range results {
+		summaries = append(summaries, result.String())
+	}
+	assert.Equal(t, []string{
+		"internal: Line coverage: 95% meets the minimum of 50%",
+		"internal/parsers: Line coverage: 85% is below the minimum of 90%",
+		"internal/parsers/bad.go: Line coverage: 70% is below the minimum of 90%",
+		"internal/parsers/good.go: Line coverage: 100% meets the minimum of 90%",
+	}, summaries, "demo_projects matches the catch-all rule too, but its own rule ignores it first")
+}
+
+func TestNewPathRules_Validation(t *testing.T) {
+	testCases := []struct {
+		name string
+		rule qualitygate.PathRule
+	}{
+		{name: "Empty path", rule: qualitygate.PathRule{Line: 80}},
+		{name: "Minimum above 100", rule: qualitygate.PathRule{Path: "src/**", Branch: 120}},
+		{name: "Negative minimum", rule: qualitygate.PathRule{Path: "src/**", Method: -1}},
+		{name: "Unbalanced brackets", rule: qualitygate.PathRule{Path: "src/[a/**", Line: 80}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := qualitygate.NewPathRules([]qualitygate.PathRule{tc.rule})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPathRules_ExcludeIgnored(t *testing.T) {
+	// Arrange
+	root := &model.DirNode{Path: ".", Subdirs: map[string]*model.DirNode{}, Files: map[string]*model.FileNode{}}
+	src := &model.DirNode{
+		Name:    "src",
+		Path:    "src",
+		Metrics: model.CoverageMetrics{LinesCovered: 8, LinesValid: 15, BranchesCovered: 2, BranchesValid: 4},
+		Subdirs: map[string]*model.DirNode{},
+		Files:   map[string]*model.FileNode{},
+		Parent:  root,
+	}
+	src.Files["generated.go"] = &model.FileNode{
+		Name:    "generated.go",
+		Path:    "src/generated.go",
+		Metrics: model.CoverageMetrics{LinesCovered: 0, LinesValid: 5, BranchesCovered: 0, BranchesValid: 2},
+		Parent:  src,
+	}
+	demo := &model.DirNode{
+		Name:    "demo",
+		Path:    "demo",
+		Metrics: model.CoverageMetrics{LinesCovered: 0, LinesValid: 20, MethodsValid: 3},
+		Subdirs: map[string]*model.DirNode{},
+		Files:   map[string]*model.FileNode{},
+		Parent:  root,
+	}
+	root.Subdirs["src"] = src
+	root.Subdirs["demo"] = demo
+	project := model.CoverageMetrics{LinesCovered: 8, LinesValid: 35, BranchesCovered: 2, BranchesValid: 4, MethodsCovered: 1, MethodsValid: 4}
+
+	rules, err := qualitygate.NewPathRules([]qualitygate.PathRule{
+		{Path: "demo/**", Ignore: true},
+		{Path: "src/generated.go", Ignore: true},
+		{Path: "src/**", Line: 90},
+	})
+	require.NoError(t, err)
+
+	// Act
+	metrics := rules.ExcludeIgnored(project, root)
+
+	// Assert
+	assert.Equal(t, model.CoverageMetrics{LinesCovered: 8, LinesValid: 10, BranchesCovered: 2, BranchesValid: 2, MethodsCovered: 1, MethodsValid: 1}, metrics)
+	results := qualitygate.Evaluate(metrics, qualitygate.Thresholds{LineCoverage: 80})
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed, "The uncovered lines of the ignored paths do not count against the global minimum")
+
+	var noRules *qualitygate.PathRules
+	assert.Equal(t, project, noRules.ExcludeIgnored(project, root))
+}
@@ -0,0 +1,126 @@
+package badge
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/IgorBayerl/nanovision/filtering"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter"
+	"github.com/IgorBayerl/nanovision/internal/risk"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// metricBadge describes a badge written for the project and each selected
+// directory.
+type metricBadge struct {
+	fileSuffix string
+	label      string
+	band       func(risk.Thresholds) risk.Band
+	coverage   func(model.CoverageMetrics) (covered, valid int)
+}
+
+var metricBadges = []metricBadge{
+	{
+		fileSuffix: "linecoverage",
+		label:      "line coverage",
+		band:       func(t risk.Thresholds) risk.Band { return t.LineCoverage },
+		coverage:   func(m model.CoverageMetrics) (int, int) { return m.LinesCovered, m.LinesValid },
+	},
+	{
+		fileSuffix: "branchcoverage",
+		label:      "branch coverage",
+		band:       func(t risk.Thresholds) risk.Band { return t.BranchCoverage },
+		coverage:   func(m model.CoverageMetrics) (int, int) { return m.BranchesCovered, m.BranchesValid },
+	},
+	{
+		fileSuffix: "methodcoverage",
+		label:      "method coverage",
+		band:       func(t risk.Thresholds) risk.Band { return t.MethodCoverage },
+		coverage:   func(m model.CoverageMetrics) (int, int) { return m.MethodsCovered, m.MethodsValid },
+	},
+}
+
+// BadgeReportBuilder writes SVG badges of the line, branch and method
+// coverage, colored by the risk bands. The project's badges are named
+// "badge_linecoverage.svg" and so on; the badges of the selected directories
+// add the directory to the name, e.g. "badge_internal_parsers_linecoverage.svg".
+// Metrics that were not measured get a gray "n/a" badge, so links to the
+// badges never break.
+type BadgeReportBuilder struct {
+	outputDir      string
+	riskThresholds risk.Thresholds
+	directories    filtering.IFilter
+	logger         *slog.Logger
+}
+
+// NewBadgeReportBuilder creates the builder. directories selects the
+// directories that get badges of their own; nil selects none.
+func NewBadgeReportBuilder(outputDir string, riskThresholds risk.Thresholds, directories filtering.IFilter, logger *slog.Logger) reporter.ReportBuilder {
+	return &BadgeReportBuilder{
+		outputDir:      outputDir,
+		riskThresholds: riskThresholds,
+		directories:    directories,
+		logger:         logger,
+	}
+}
+
+func (b *BadgeReportBuilder) ReportType() string {
+	return "Badge"
+}
+
+func (b *BadgeReportBuilder) CreateReport(tree *model.SummaryTree) error {
+	if err := b.writeBadges("badge", "", tree.Metrics); err != nil {
+		return err
+	}
+	if b.directories == nil {
+		return nil
+	}
+
+	var dirs []*model.DirNode
+	var walk func(dir *model.DirNode)
+	walk = func(dir *model.DirNode) {
+		for _, subdir := range dir.Subdirs {
+			if b.directories.IsElementIncludedInReport(subdir.Path) {
+				dirs = append(dirs, subdir)
+			}
+			walk(subdir)
+		}
+	}
+	if tree.Root != nil {
+		walk(tree.Root)
+	}
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].Path < dirs[j].Path })
+
+	for _, dir := range dirs {
+		if err := b.writeBadges("badge_"+utils.ReplaceInvalidPathChars(dir.Path), dir.Path+" ", dir.Metrics); err != nil {
+			return err
+		}
+	}
+	b.logger.Info("Wrote directory badges", "directories", len(dirs))
+	return nil
+}
+
+// writeBadges writes one badge per metric, named filePrefix_<metric>.svg.
+func (b *BadgeReportBuilder) writeBadges(filePrefix, labelPrefix string, metrics model.CoverageMetrics) error {
+	for _, badge := range metricBadges {
+		covered, valid := badge.coverage(metrics)
+		percentage := utils.CalculatePercentage(covered, valid, 1)
+
+		value, color := "n/a", colorUnmeasured
+		if !math.IsNaN(percentage) {
+			value = utils.FormatPercentage(percentage, 1)
+			color = levelColors[badge.band(b.riskThresholds).Coverage(percentage)]
+		}
+
+		outputPath := filepath.Join(b.outputDir, filePrefix+"_"+badge.fileSuffix+".svg")
+		if err := os.WriteFile(outputPath, []byte(renderSVG(labelPrefix+badge.label, value, color)), 0644); err != nil {
+			return fmt.Errorf("failed to write badge '%s': %w", outputPath, err)
+		}
+	}
+	return nil
+}
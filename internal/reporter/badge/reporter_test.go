@@ -0,0 +1,76 @@
+package badge_test
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/filtering"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter/badge"
+	"github.com/IgorBayerl/nanovision/internal/risk"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTree() *model.SummaryTree {
+	parser := testutil.NewFile("internal/parsers/parser.go", 1, 1, 1, 1, 1, 1, 1, 1, 1, 0)
+	parser.Metrics.MethodsCovered, parser.Metrics.MethodsValid = 7, 10
+	return testutil.NewTree(parser, testutil.NewFile("internal/utils/paths.go", 1, 0, 0, 0, 0, 0, 0, 0, 0, 0))
+}
+
+func readBadge(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestBadgeReportBuilder_CreateReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	builder := badge.NewBadgeReportBuilder(tmpDir, risk.DefaultThresholds(), nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.NoError(t, builder.CreateReport(newTree()))
+
+	testCases := []struct {
+		file  string
+		text  string
+		color string
+	}{
+		{file: "badge_linecoverage.svg", text: "50.0%", color: "#e05d44"},
+		{file: "badge_branchcoverage.svg", text: "n/a", color: "#9f9f9f"},
+		{file: "badge_methodcoverage.svg", text: "70.0%", color: "#dfb317"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.file, func(t *testing.T) {
+			svg := readBadge(t, tmpDir, tc.file)
+			assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg"`)
+			assert.Contains(t, svg, ">"+tc.text+"</text>")
+			assert.Contains(t, svg, `fill="`+tc.color+`"`)
+			assert.NotContains(t, svg, "href", "Badges should not reference external resources")
+		})
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "Directory badges should only be written when directories are selected")
+}
+
+func TestBadgeReportBuilder_DirectoryBadges(t *testing.T) {
+	tmpDir := t.TempDir()
+	directories, err := filtering.NewDefaultFilter([]string{"+*/parsers"}, true)
+	require.NoError(t, err)
+	builder := badge.NewBadgeReportBuilder(tmpDir, risk.DefaultThresholds(), directories, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.NoError(t, builder.CreateReport(newTree()))
+
+	svg := readBadge(t, tmpDir, "badge_internal_parsers_linecoverage.svg")
+	assert.Contains(t, svg, ">internal/parsers line coverage</text>")
+	assert.Contains(t, svg, ">90.0%</text>")
+	assert.Contains(t, svg, `fill="#4c1"`)
+
+	assert.NoFileExists(t, filepath.Join(tmpDir, "badge_internal_utils_linecoverage.svg"))
+}
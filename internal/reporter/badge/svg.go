@@ -0,0 +1,82 @@
+package badge
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/risk"
+)
+
+// Badge colors, as used by the common "flat" badge style.
+const (
+	colorSafe       = "#4c1"
+	colorWarning    = "#dfb317"
+	colorDanger     = "#e05d44"
+	colorUnmeasured = "#9f9f9f"
+	colorLabel      = "#555"
+)
+
+var levelColors = map[risk.Level]string{
+	risk.Safe:    colorSafe,
+	risk.Warning: colorWarning,
+	risk.Danger:  colorDanger,
+}
+
+// horizontalPadding is the space left and right of each text.
+const horizontalPadding = 6
+
+// renderSVG draws a flat badge with a gray label and a colored value. The
+// SVG references no fonts, images or scripts, so it renders anywhere.
+func renderSVG(label, value, color string) string {
+	labelWidth := textWidth(label) + 2*horizontalPadding
+	valueWidth := textWidth(value) + 2*horizontalPadding
+	width := labelWidth + valueWidth
+	label, value = html.EscapeString(label), html.EscapeString(value)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n", width, label, value)
+	fmt.Fprintf(&sb, "  <title>%s: %s</title>\n", label, value)
+	sb.WriteString(`  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&sb, `  <clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", width)
+	sb.WriteString(`  <g clip-path="url(#r)">` + "\n")
+	fmt.Fprintf(&sb, `    <rect width="%d" height="20" fill="%s"/>`+"\n", labelWidth, colorLabel)
+	fmt.Fprintf(&sb, `    <rect x="%d" width="%d" height="20" fill="%s"/>`+"\n", labelWidth, valueWidth, color)
+	fmt.Fprintf(&sb, `    <rect width="%d" height="20" fill="url(#s)"/>`+"\n", width)
+	sb.WriteString("  </g>\n")
+	sb.WriteString(`  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	for _, text := range []struct {
+		x       float64
+		content string
+	}{
+		{float64(labelWidth) / 2, label},
+		{float64(labelWidth) + float64(valueWidth)/2, value},
+	} {
+		fmt.Fprintf(&sb, `    <text x="%g" y="15" fill="#010101" fill-opacity=".3">%s</text>`+"\n", text.x, text.content)
+		fmt.Fprintf(&sb, `    <text x="%g" y="14">%s</text>`+"\n", text.x, text.content)
+	}
+	sb.WriteString("  </g>\n</svg>\n")
+	return sb.String()
+}
+
+// textWidth estimates the width of text in 11px Verdana. The SVG cannot
+// measure its own text, so the badge is sized from typical glyph widths.
+func textWidth(text string) int {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("iljI.,:;!|' ", r):
+			width += 4
+		case strings.ContainsRune("frt()/", r):
+			width += 5
+		case strings.ContainsRune("mwMW%", r):
+			width += 11
+		case r >= 'A' && r <= 'Z':
+			width += 8
+		default:
+			width += 7
+		}
+	}
+	return int(math.Ceil(width))
+}
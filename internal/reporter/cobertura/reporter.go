@@ -0,0 +1,235 @@
+package cobertura
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter"
+)
+
+// CoberturaReportBuilder writes the merged tree as a Cobertura 4 XML report,
+// so that tools that only read Cobertura (GitLab, Azure DevOps) can consume
+// coverage merged from any of the input formats.
+//
+// Every directory that contains files becomes a package named after its
+// project-relative path, and every file a class. The single source is the
+// project root, which the project-relative file names resolve against.
+type CoberturaReportBuilder struct {
+	outputDir   string
+	projectRoot string
+	logger      *slog.Logger
+}
+
+func NewCoberturaReportBuilder(outputDir, projectRoot string, logger *slog.Logger) reporter.ReportBuilder {
+	return &CoberturaReportBuilder{
+		outputDir:   outputDir,
+		projectRoot: projectRoot,
+		logger:      logger,
+	}
+}
+
+func (b *CoberturaReportBuilder) ReportType() string {
+	return "Cobertura"
+}
+
+func (b *CoberturaReportBuilder) CreateReport(tree *model.SummaryTree) error {
+	var branches branchCounts
+	for _, file := range tree.FilesByPath() {
+		branches.add(countBranches(file.Lines))
+	}
+
+	timestamp := tree.Timestamp
+	if timestamp == 0 {
+		timestamp = time.Now().Unix()
+	}
+
+	report := coverageXML{
+		LineRate:        rate(tree.Metrics.LinesCovered, tree.Metrics.LinesValid),
+		BranchRate:      rate(branches.covered, branches.valid),
+		LinesCovered:    tree.Metrics.LinesCovered,
+		LinesValid:      tree.Metrics.LinesValid,
+		BranchesCovered: branches.covered,
+		BranchesValid:   branches.valid,
+		Version:         "nanovision",
+		Timestamp:       timestamp * 1000, // Cobertura timestamps are in milliseconds.
+		Sources:         sourcesXML{Source: []string{b.projectRoot}},
+	}
+
+	totalComplexity := 0
+	for _, dir := range collectPackageDirs(tree.Root) {
+		pkg, complexity := buildPackage(dir)
+		report.Packages.Package = append(report.Packages.Package, pkg)
+		totalComplexity += complexity
+	}
+	report.Complexity = strconv.Itoa(totalComplexity)
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal Cobertura report: %w", err)
+	}
+	content := []byte(xml.Header + doctype + "\n")
+	content = append(content, data...)
+	content = append(content, '\n')
+
+	outputPath := filepath.Join(b.outputDir, "Cobertura.xml")
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write Cobertura report '%s': %w", outputPath, err)
+	}
+	b.logger.Info("Wrote Cobertura report", "path", outputPath, "packages", len(report.Packages.Package))
+	return nil
+}
+
+// collectPackageDirs returns the directories that directly contain files,
+// sorted by path.
+func collectPackageDirs(root *model.DirNode) []*model.DirNode {
+	var dirs []*model.DirNode
+	var walk func(dir *model.DirNode)
+	walk = func(dir *model.DirNode) {
+		if dir == nil {
+			return
+		}
+		if len(dir.Files) > 0 {
+			dirs = append(dirs, dir)
+		}
+		for _, subdir := range dir.Subdirs {
+			walk(subdir)
+		}
+	}
+	walk(root)
+	sort.Slice(dirs, func(i, j int) bool { return dirs[i].Path < dirs[j].Path })
+	return dirs
+}
+
+// buildPackage converts the files of a directory. The package rates cover
+// only those files, not the subdirectories, which are packages of their own.
+func buildPackage(dir *model.DirNode) (packageXML, int) {
+	files := make([]*model.FileNode, 0, len(dir.Files))
+	for _, file := range dir.Files {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	var linesCovered, linesValid int
+	var branches branchCounts
+	complexity := 0
+	pkg := packageXML{Name: dir.Path}
+	for _, file := range files {
+		class, classComplexity := buildClass(file)
+		pkg.Classes.Class = append(pkg.Classes.Class, class)
+		complexity += classComplexity
+
+		linesCovered += file.Metrics.LinesCovered
+		linesValid += file.Metrics.LinesValid
+		branches.add(countBranches(file.Lines))
+	}
+	pkg.LineRate = rate(linesCovered, linesValid)
+	pkg.BranchRate = rate(branches.covered, branches.valid)
+	pkg.Complexity = strconv.Itoa(complexity)
+	return pkg, complexity
+}
+
+// buildClass converts a file. Its complexity is the sum of the cyclomatic
+// complexity of its methods.
+func buildClass(file *model.FileNode) (classXML, int) {
+	lineNumbers := make([]int, 0, len(file.Lines))
+	for lineNumber, line := range file.Lines {
+		if line.Hits >= 0 {
+			lineNumbers = append(lineNumbers, lineNumber)
+		}
+	}
+	sort.Ints(lineNumbers)
+
+	branches := countBranches(file.Lines)
+	class := classXML{
+		Name:       file.Path,
+		Filename:   file.Path,
+		LineRate:   rate(file.Metrics.LinesCovered, file.Metrics.LinesValid),
+		BranchRate: rate(branches.covered, branches.valid),
+	}
+	for _, lineNumber := range lineNumbers {
+		class.Lines.Line = append(class.Lines.Line, buildLine(lineNumber, file.Lines[lineNumber]))
+	}
+
+	methods := make([]model.MethodMetrics, len(file.Methods))
+	copy(methods, file.Methods)
+	sort.SliceStable(methods, func(i, j int) bool { return methods[i].StartLine < methods[j].StartLine })
+
+	complexity := 0
+	for _, method := range methods {
+		methodComplexity := 0
+		if method.CyclomaticComplexity != nil {
+			methodComplexity = *method.CyclomaticComplexity
+		}
+		complexity += methodComplexity
+
+		element := methodXML{
+			Name:       method.Name,
+			LineRate:   rate(method.LinesCovered, method.LinesValid),
+			BranchRate: rate(method.BranchesCovered, method.BranchesValid),
+			Complexity: strconv.Itoa(methodComplexity),
+		}
+		for _, lineNumber := range lineNumbers {
+			if lineNumber >= method.StartLine && lineNumber <= method.EndLine {
+				element.Lines.Line = append(element.Lines.Line, buildLine(lineNumber, file.Lines[lineNumber]))
+			}
+		}
+		class.Methods.Method = append(class.Methods.Method, element)
+	}
+	class.Complexity = strconv.Itoa(complexity)
+	return class, complexity
+}
+
+// buildLine converts a line. Lines with branches are marked as branch lines
+// and carry their condition coverage, e.g. "50% (1/2)". Merged reports can
+// count a branch as covered more than once, so the count is capped.
+func buildLine(lineNumber int, line model.LineMetrics) lineXML {
+	element := lineXML{Number: lineNumber, Hits: line.Hits}
+	if line.TotalBranches > 0 {
+		covered := min(line.CoveredBranches, line.TotalBranches)
+		element.Branch = true
+		element.ConditionCoverage = fmt.Sprintf("%d%% (%d/%d)", covered*100/line.TotalBranches, covered, line.TotalBranches)
+	}
+	return element
+}
+
+// branchCounts holds the covered and total branches of a set of lines.
+type branchCounts struct {
+	covered, valid int
+}
+
+func (c *branchCounts) add(other branchCounts) {
+	c.covered += other.covered
+	c.valid += other.valid
+}
+
+// countBranches counts the branches of the coverable lines. The covered count
+// of each line is capped like in buildLine, so the rates agree with the
+// condition coverage of the lines.
+func countBranches(lines map[int]model.LineMetrics) branchCounts {
+	var counts branchCounts
+	for _, line := range lines {
+		if line.Hits >= 0 && line.TotalBranches > 0 {
+			counts.covered += min(line.CoveredBranches, line.TotalBranches)
+			counts.valid += line.TotalBranches
+		}
+	}
+	return counts
+}
+
+// rate formats covered/valid as a fraction with up to four decimals, or 0
+// when nothing was measured.
+func rate(covered, valid int) string {
+	if valid == 0 {
+		return "0"
+	}
+	value := math.Round(float64(covered)/float64(valid)*10000) / 10000
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,119 @@
+package cobertura_test
+
+import (
+	"encoding/xml"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers/parser_cobertura"
+	"github.com/IgorBayerl/nanovision/internal/reporter/cobertura"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intPtr(v int) *int { return &v }
+
+func newTree() *model.SummaryTree {
+	calc := &model.FileNode{
+		Name: "calc.go",
+		Path: "src/calc.go",
+		Lines: map[int]model.LineMetrics{
+			1: {Hits: -1},
+			3: {Hits: 2, TotalBranches: 2, CoveredBranches: 1},
+			4: {Hits: 2},
+			5: {Hits: 0},
+			9: {Hits: 1, TotalBranches: 2, CoveredBranches: 3},
+		},
+		Methods: []model.MethodMetrics{
+			{Name: "Other", StartLine: 8, EndLine: 10, LinesValid: 1, LinesCovered: 1, BranchesValid: 2, BranchesCovered: 2},
+			{Name: "Add", StartLine: 2, EndLine: 6, CyclomaticComplexity: intPtr(3), LinesValid: 3, LinesCovered: 2, BranchesValid: 2, BranchesCovered: 1},
+		},
+		// The merged branch count of line 9 exceeds its total, as in the tree.
+		Metrics: model.CoverageMetrics{LinesCovered: 3, LinesValid: 4, BranchesCovered: 4, BranchesValid: 4},
+	}
+
+	tree := testutil.NewTree(calc, testutil.NewFile("main.go", 0))
+	tree.Timestamp = 1700000000
+	return tree
+}
+
+func TestCoberturaReportBuilder_CreateReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	builder := cobertura.NewCoberturaReportBuilder(tmpDir, "/project", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.NoError(t, builder.CreateReport(newTree()))
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "Cobertura.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`)
+
+	// The report must be readable by the Cobertura parser.
+	var report parser_cobertura.CoberturaRoot
+	require.NoError(t, xml.Unmarshal(content, &report))
+
+	assert.Equal(t, "0.6", report.LineRate)
+	assert.Equal(t, "0.75", report.BranchRate, "Branch rates should use the capped branch counts")
+	assert.Equal(t, "3", report.BranchesCovered)
+	assert.Equal(t, "3", report.LinesCovered)
+	assert.Equal(t, "5", report.LinesValid)
+	assert.Equal(t, "3", report.Complexity)
+	assert.Equal(t, "1700000000000", report.Timestamp)
+	assert.Equal(t, []string{"/project"}, report.Sources.Source)
+
+	require.Len(t, report.Packages.Package, 2)
+	assert.Equal(t, ".", report.Packages.Package[0].Name)
+	assert.Equal(t, "0", report.Packages.Package[0].LineRate)
+	pkg := report.Packages.Package[1]
+	assert.Equal(t, "src", pkg.Name)
+	assert.Equal(t, "0.75", pkg.LineRate)
+	assert.Equal(t, "0.75", pkg.BranchRate)
+
+	require.Len(t, pkg.Classes.Class, 1)
+	class := pkg.Classes.Class[0]
+	assert.Equal(t, "src/calc.go", class.Filename)
+	assert.Equal(t, "3", class.Complexity)
+	assert.Equal(t, "0.75", class.BranchRate)
+
+	require.Len(t, class.Lines.Line, 4, "Lines that are not coverable should be skipped")
+	branchLine := class.Lines.Line[0]
+	assert.Equal(t, "3", branchLine.Number)
+	assert.Equal(t, "true", branchLine.Branch)
+	assert.Equal(t, "50% (1/2)", branchLine.ConditionCoverage)
+	assert.Equal(t, "false", class.Lines.Line[1].Branch)
+	assert.Empty(t, class.Lines.Line[1].ConditionCoverage)
+	assert.Equal(t, "100% (2/2)", class.Lines.Line[3].ConditionCoverage, "Covered branches should be capped at the total")
+
+	require.Len(t, class.Methods.Method, 2)
+	add := class.Methods.Method[0]
+	assert.Equal(t, "Add", add.Name, "Methods should be sorted by start line")
+	assert.Equal(t, "0.6667", add.LineRate)
+	assert.Equal(t, "3", add.Complexity)
+	require.Len(t, add.Lines.Line, 3)
+	assert.Equal(t, "5", add.Lines.Line[2].Number)
+	assert.Equal(t, "0", class.Methods.Method[1].Complexity)
+}
+
+func TestCoberturaReportBuilder_CreateReport_GenerationTime(t *testing.T) {
+	tmpDir := t.TempDir()
+	builder := cobertura.NewCoberturaReportBuilder(tmpDir, "/project", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	tree := newTree()
+	tree.Timestamp = 0
+	before := time.Now().Unix() * 1000
+
+	require.NoError(t, builder.CreateReport(tree))
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "Cobertura.xml"))
+	require.NoError(t, err)
+	var report parser_cobertura.CoberturaRoot
+	require.NoError(t, xml.Unmarshal(content, &report))
+	timestamp, err := strconv.ParseInt(report.Timestamp, 10, 64)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, timestamp, before, "A tree without a timestamp should get the generation time")
+}
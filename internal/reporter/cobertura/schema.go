@@ -0,0 +1,88 @@
+package cobertura
+
+import "encoding/xml"
+
+// The elements of the Cobertura 4 DTD
+// (http://cobertura.sourceforge.net/xml/coverage-04.dtd), in the order the
+// DTD requires. Rates are fractions between 0 and 1.
+
+const doctype = `<!DOCTYPE coverage SYSTEM "http://cobertura.sourceforge.net/xml/coverage-04.dtd">`
+
+// <coverage>
+type coverageXML struct {
+	XMLName         xml.Name    `xml:"coverage"`
+	LineRate        string      `xml:"line-rate,attr"`
+	BranchRate      string      `xml:"branch-rate,attr"`
+	LinesCovered    int         `xml:"lines-covered,attr"`
+	LinesValid      int         `xml:"lines-valid,attr"`
+	BranchesCovered int         `xml:"branches-covered,attr"`
+	BranchesValid   int         `xml:"branches-valid,attr"`
+	Complexity      string      `xml:"complexity,attr"`
+	Version         string      `xml:"version,attr"`
+	Timestamp       int64       `xml:"timestamp,attr"`
+	Sources         sourcesXML  `xml:"sources"`
+	Packages        packagesXML `xml:"packages"`
+}
+
+// <sources>
+type sourcesXML struct {
+	Source []string `xml:"source"`
+}
+
+// <packages>
+type packagesXML struct {
+	Package []packageXML `xml:"package"`
+}
+
+// <package>
+type packageXML struct {
+	Name       string     `xml:"name,attr"`
+	LineRate   string     `xml:"line-rate,attr"`
+	BranchRate string     `xml:"branch-rate,attr"`
+	Complexity string     `xml:"complexity,attr"`
+	Classes    classesXML `xml:"classes"`
+}
+
+// <classes>
+type classesXML struct {
+	Class []classXML `xml:"class"`
+}
+
+// <class>
+type classXML struct {
+	Name       string     `xml:"name,attr"`
+	Filename   string     `xml:"filename,attr"`
+	LineRate   string     `xml:"line-rate,attr"`
+	BranchRate string     `xml:"branch-rate,attr"`
+	Complexity string     `xml:"complexity,attr"`
+	Methods    methodsXML `xml:"methods"`
+	Lines      linesXML   `xml:"lines"`
+}
+
+// <methods>
+type methodsXML struct {
+	Method []methodXML `xml:"method"`
+}
+
+// <method>
+type methodXML struct {
+	Name       string   `xml:"name,attr"`
+	Signature  string   `xml:"signature,attr"`
+	LineRate   string   `xml:"line-rate,attr"`
+	BranchRate string   `xml:"branch-rate,attr"`
+	Complexity string   `xml:"complexity,attr"`
+	Lines      linesXML `xml:"lines"`
+}
+
+// <lines>
+type linesXML struct {
+	Line []lineXML `xml:"line"`
+}
+
+// <line>
+type lineXML struct {
+	Number            int    `xml:"number,attr"`
+	Hits              int    `xml:"hits,attr"`
+	Branch            bool   `xml:"branch,attr"`
+	ConditionCoverage string `xml:"condition-coverage,attr,omitempty"`
+}
@@ -0,0 +1,95 @@
+package diagnostics
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter"
+)
+
+// kindDescriptions tells the reader what each kind of diagnostic means for
+// the coverage numbers.
+var kindDescriptions = map[model.DiagnosticKind]string{
+	model.DiagnosticUnresolved: "not found in the source directory, their coverage is missing",
+	model.DiagnosticAmbiguous:  "matched several source files, their coverage is missing",
+	model.DiagnosticFuzzyMatch: "matched by file name or path suffix only, check that the right file was picked",
+	model.DiagnosticFiltered:   "excluded by the file filters",
+}
+
+// DiagnosticsReportBuilder writes the files of the coverage reports that did
+// not map cleanly onto the project's sources, to help fix source directories,
+// path mappings and filters.
+type DiagnosticsReportBuilder struct {
+	outputDir string
+	logger    *slog.Logger
+}
+
+func NewDiagnosticsReportBuilder(outputDir string, logger *slog.Logger) reporter.ReportBuilder {
+	return &DiagnosticsReportBuilder{
+		outputDir: outputDir,
+		logger:    logger,
+	}
+}
+
+func (b *DiagnosticsReportBuilder) ReportType() string {
+	return "Diagnostics"
+}
+
+func (b *DiagnosticsReportBuilder) CreateReport(tree *model.SummaryTree) error {
+	outputPath := filepath.Join(b.outputDir, "Diagnostics.txt")
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close()
+
+	b.logger.Info("Writing diagnostics to file", "path", outputPath, "count", len(tree.Diagnostics))
+
+	if err := writeDiagnostics(f, tree.Diagnostics); err != nil {
+		return fmt.Errorf("failed to write diagnostics to '%s': %w", outputPath, err)
+	}
+	return nil
+}
+
+func writeDiagnostics(w io.Writer, diagnostics []model.FileDiagnostic) error {
+	byKind := make(map[model.DiagnosticKind][]model.FileDiagnostic)
+	for _, diagnostic := range diagnostics {
+		byKind[diagnostic.Kind] = append(byKind[diagnostic.Kind], diagnostic)
+	}
+
+	fmt.Fprintf(w, "Diagnostics\n")
+	for _, kind := range model.DiagnosticKinds {
+		fmt.Fprintf(w, "  %s: %d\n", kind.Title(), len(byKind[kind]))
+	}
+	if len(diagnostics) == 0 {
+		fmt.Fprintf(w, "\nEvery file of the coverage reports was matched to exactly one source file.\n")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, kind := range model.DiagnosticKinds {
+		group := byKind[kind]
+		if len(group) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(tw, "\n%s (%s)\n", kind.Title(), kindDescriptions[kind])
+		fmt.Fprintf(tw, "  Path\tReport\tDetail\n")
+		for _, diagnostic := range group {
+			detail := diagnostic.Detail
+			if diagnostic.ResolvedPath != "" {
+				detail = fmt.Sprintf("%s -> %s", detail, diagnostic.ResolvedPath)
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", diagnostic.Path, diagnostic.Report, detail)
+			for _, candidate := range diagnostic.Candidates {
+				fmt.Fprintf(tw, "  \t\t  candidate: %s\n", candidate)
+			}
+		}
+	}
+	return tw.Flush()
+}
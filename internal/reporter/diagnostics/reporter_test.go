@@ -0,0 +1,79 @@
+package diagnostics_test
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter/diagnostics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createReport(t *testing.T, diagnosticsList []model.FileDiagnostic) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	builder := diagnostics.NewDiagnosticsReportBuilder(tmpDir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.NoError(t, builder.CreateReport(&model.SummaryTree{Diagnostics: diagnosticsList}))
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "Diagnostics.txt"))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestDiagnosticsReportBuilder_CreateReport(t *testing.T) {
+	content := createReport(t, []model.FileDiagnostic{
+		{Kind: model.DiagnosticFiltered, Path: "vendor/lib.go", Report: "cover.out", Detail: "excluded by file filter"},
+		{Kind: model.DiagnosticUnresolved, Path: "/ci/gone.go", Report: "cover.out", Detail: "not found"},
+		{
+			Kind:       model.DiagnosticAmbiguous,
+			Path:       "src/app.go",
+			Report:     "lcov.info",
+			Detail:     "matched several files",
+			Candidates: []string{"/project/a/src/app.go", "/project/b/src/app.go"},
+		},
+		{Kind: model.DiagnosticUnresolved, Path: "/ci/missing.go", Report: "lcov.info", Detail: "not found"},
+		{Kind: model.DiagnosticFuzzyMatch, Path: "/ci/util.go", Report: "cover.out", ResolvedPath: "/project/pkg/util.go", Detail: "search"},
+	})
+
+	assert.Contains(t, content, "  Unresolved Files: 2\n")
+	assert.Contains(t, content, "  Ambiguous Files: 1\n")
+	assert.Contains(t, content, "  Fuzzy-Matched Files: 1\n")
+	assert.Contains(t, content, "  Filtered Files: 1\n")
+
+	// Groups follow the order of model.DiagnosticKinds, whatever the order of the input.
+	headings := []string{"\nUnresolved Files (", "\nAmbiguous Files (", "\nFuzzy-Matched Files (", "\nFiltered Files ("}
+	previous := -1
+	for _, heading := range headings {
+		index := strings.Index(content, heading)
+		require.NotEqual(t, -1, index, "Missing group %q", heading)
+		assert.Greater(t, index, previous, "Group %q is out of order", heading)
+		previous = index
+	}
+
+	unresolved := content[strings.Index(content, headings[0]):strings.Index(content, headings[1])]
+	assert.Contains(t, unresolved, "/ci/gone.go")
+	assert.Contains(t, unresolved, "/ci/missing.go")
+	assert.NotContains(t, unresolved, "src/app.go", "Diagnostics should only be listed under their own kind")
+
+	ambiguous := content[strings.Index(content, headings[1]):strings.Index(content, headings[2])]
+	assert.Contains(t, ambiguous, "candidate: /project/a/src/app.go\n")
+	assert.Contains(t, ambiguous, "candidate: /project/b/src/app.go\n")
+
+	assert.Contains(t, content, "search -> /project/pkg/util.go")
+}
+
+func TestDiagnosticsReportBuilder_CreateReport_Empty(t *testing.T) {
+	content := createReport(t, nil)
+
+	for _, kind := range model.DiagnosticKinds {
+		assert.Contains(t, content, "  "+kind.Title()+": 0\n")
+	}
+	assert.Contains(t, content, "Every file of the coverage reports was matched to exactly one source file.")
+	assert.NotContains(t, content, "Path")
+}
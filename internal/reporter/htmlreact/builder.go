@@ -8,20 +8,27 @@ import (
 	"strings"
 	"time"
 
+	"github.com/IgorBayerl/nanovision/filereader"
+	"github.com/IgorBayerl/nanovision/internal/history"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/reporter"
+	"github.com/IgorBayerl/nanovision/internal/risk"
 	"github.com/IgorBayerl/nanovision/internal/utils"
 )
 
 type HtmlReactReportBuilder struct {
-	outputDir string
-	logger    *slog.Logger
+	outputDir      string
+	fileReader     filereader.Reader
+	riskThresholds risk.Thresholds
+	logger         *slog.Logger
 }
 
-func NewHtmlReactReportBuilder(outputDir string, logger *slog.Logger) reporter.ReportBuilder {
+func NewHtmlReactReportBuilder(outputDir string, fileReader filereader.Reader, riskThresholds risk.Thresholds, logger *slog.Logger) reporter.ReportBuilder {
 	return &HtmlReactReportBuilder{
-		outputDir: outputDir,
-		logger:    logger,
+		outputDir:      outputDir,
+		fileReader:     fileReader,
+		riskThresholds: riskThresholds,
+		logger:         logger,
 	}
 }
 
@@ -52,17 +59,21 @@ func (b *HtmlReactReportBuilder) CreateReport(tree *model.SummaryTree) error {
 
 func (b *HtmlReactReportBuilder) transformTree(tree *model.SummaryTree) (summaryV1, error) {
 	generatedAt := time.Now().UTC()
-	treeNodes := b.buildTreeChildren(tree.Root)
+	gates := newGateIndex(tree.GateResults)
+	treeNodes := b.buildTreeChildren(tree.Root, gates)
 	totalFiles, totalFolders := countNodes(treeNodes)
 
 	return summaryV1{
 		SchemaVersion:     1,
 		GeneratedAt:       generatedAt.Format(time.RFC3339),
 		Title:             "Coverage Report",
-		Totals:            b.buildTotals(tree, totalFiles, totalFolders),
+		Totals:            b.buildTotals(tree, gates, totalFiles, totalFolders),
 		Tree:              treeNodes,
 		MetricDefinitions: b.buildMetricDefinitions(),
+		RiskThresholds:    b.buildRiskThresholds(),
 		Metadata:          b.buildMetadata(tree, generatedAt),
+		History:           buildHistory(tree, generatedAt),
+		Diagnostics:       buildDiagnostics(tree.Diagnostics),
 	}, nil
 }
 
@@ -104,22 +115,93 @@ func (b *HtmlReactReportBuilder) buildMetadata(tree *model.SummaryTree, generate
 		addMeta(&meta, "Parser", parserValue)
 	}
 	addMeta(&meta, "Report Files", tree.ReportFiles, "large")
+	if tree.Patch != nil {
+		patchCoverage := utils.CalculatePercentage(tree.Patch.Metrics.LinesCovered, tree.Patch.Metrics.LinesValid, 2)
+		addMeta(&meta, "Patch Coverage", fmt.Sprintf("%s (%d of %d changed lines, %s)", utils.FormatPercentage(patchCoverage, 2), tree.Patch.Metrics.LinesCovered, tree.Patch.Metrics.LinesValid, tree.Patch.Base))
+	}
+	addMeta(&meta, "Failed Quality Gates", failedGateEntries(tree.GateResults), "large")
 
 	return meta
 }
 
-func (b *HtmlReactReportBuilder) buildTreeChildren(dir *model.DirNode) []fileNode {
+// buildHistory lists the coverage of the recorded runs followed by the
+// current one, oldest first, for the trend charts of the summary page.
+func buildHistory(tree *model.SummaryTree, generatedAt time.Time) []historyPoint {
+	if len(tree.History) == 0 {
+		return nil
+	}
+	percentage := func(value float64) *float64 {
+		if math.IsNaN(value) {
+			return nil
+		}
+		return &value
+	}
+	point := func(metrics model.CoverageMetrics, tag string, timestamp int64, commit string) historyPoint {
+		return historyPoint{
+			Tag:            tag,
+			Timestamp:      time.Unix(timestamp, 0).UTC().Format(time.RFC3339),
+			Commit:         commit,
+			LineCoverage:   percentage(history.LineCoverage(metrics)),
+			BranchCoverage: percentage(history.BranchCoverage(metrics)),
+			MethodCoverage: percentage(history.MethodCoverage(metrics)),
+		}
+	}
+
+	points := make([]historyPoint, 0, len(tree.History)+1)
+	for _, snapshot := range tree.History {
+		points = append(points, point(snapshot.Metrics, snapshot.Tag, snapshot.Timestamp, snapshot.Commit))
+	}
+	return append(points, point(tree.Metrics, "", generatedAt.Unix(), ""))
+}
+
+// failedGateEntries describes the failed quality gates with their actual and
+// required coverage, listing the project-wide gates first.
+func failedGateEntries(results []model.GateResult) []string {
+	var entries []string
+	for _, result := range results {
+		if !result.Passed {
+			entries = append(entries, result.String())
+		}
+	}
+	return entries
+}
+
+// buildDiagnostics groups the report files that were lost, ambiguous,
+// fuzzy-matched or filtered by kind, keeping the report that referenced each.
+func buildDiagnostics(diagnostics []model.FileDiagnostic) []diagnosticGroup {
+	byKind := make(map[model.DiagnosticKind][]diagnosticFile)
+	for _, diagnostic := range diagnostics {
+		byKind[diagnostic.Kind] = append(byKind[diagnostic.Kind], diagnosticFile{
+			Path:         diagnostic.Path,
+			Report:       diagnostic.Report,
+			ResolvedPath: diagnostic.ResolvedPath,
+			Candidates:   diagnostic.Candidates,
+			Detail:       diagnostic.Detail,
+		})
+	}
+
+	var groups []diagnosticGroup
+	for _, kind := range model.DiagnosticKinds {
+		if files := byKind[kind]; len(files) > 0 {
+			groups = append(groups, diagnosticGroup{Kind: string(kind), Title: kind.Title(), Files: files})
+		}
+	}
+	return groups
+}
+
+func (b *HtmlReactReportBuilder) buildTreeChildren(dir *model.DirNode, gates gateIndex) []fileNode {
 	children := make([]fileNode, 0, len(dir.Subdirs)+len(dir.Files))
 
 	// Add subdirectories
 	for _, subdir := range dir.Subdirs {
 		nodeMetrics, nodeStatuses := b.buildMetricsMap(subdir.Metrics)
+		applyGateStatuses(nodeStatuses, gates[subdir.Path])
 		children = append(children, fileNode{
 			ID:       subdir.Path,
 			Name:     subdir.Name,
 			Type:     "folder",
 			Path:     subdir.Path,
-			Children: b.buildTreeChildren(subdir),
+			Children: b.buildTreeChildren(subdir, gates),
 			Metrics:  nodeMetrics,
 			Statuses: nodeStatuses,
 		})
@@ -128,6 +210,7 @@ func (b *HtmlReactReportBuilder) buildTreeChildren(dir *model.DirNode) []fileNod
 	// Add files
 	for _, file := range dir.Files {
 		nodeMetrics, nodeStatuses := b.buildMetricsMap(file.Metrics)
+		applyGateStatuses(nodeStatuses, gates[file.Path])
 
 		detailsFileName := strings.ReplaceAll(file.Path, "/", "_") + ".html"
 
@@ -153,8 +236,9 @@ func (b *HtmlReactReportBuilder) buildTreeChildren(dir *model.DirNode) []fileNod
 	return children
 }
 
-func (b *HtmlReactReportBuilder) buildTotals(tree *model.SummaryTree, files, folders int) totals {
+func (b *HtmlReactReportBuilder) buildTotals(tree *model.SummaryTree, gates gateIndex, files, folders int) totals {
 	metrics, totalStatuses := b.buildMetricsMap(tree.Metrics)
+	applyGateStatuses(totalStatuses, gates[""])
 
 	t := totals{
 		Files:    files,
@@ -177,16 +261,70 @@ func (b *HtmlReactReportBuilder) buildTotals(tree *model.SummaryTree, files, fol
 	return t
 }
 
-// getRiskStatus determines the risk level based on coverage percentage.
-// TODO: get this information from the config file when implementing the components system
-func getRiskStatus(percentage float64) riskLevel {
-	if percentage >= 80 {
-		return RiskSafe
+// coverageStatus determines the risk level of a coverage percentage with the
+// configured band of its metric. Metrics that fail a quality gate are shown as
+// danger instead, see applyGateStatuses.
+func coverageStatus(band risk.Band, percentage float64) riskLevel {
+	return riskLevel(band.Coverage(percentage))
+}
+
+// complexityStatus determines the risk level of a cyclomatic complexity.
+func complexityStatus(band risk.Band, complexity int) riskLevel {
+	return riskLevel(band.Complexity(float64(complexity)))
+}
+
+// buildRiskThresholds describes the configured bands per metric ID, so the
+// UI can explain the colors it shows. Metrics without a band of their own
+// share the one documented on risk.Thresholds.
+func (b *HtmlReactReportBuilder) buildRiskThresholds() map[string]riskBand {
+	coverage := func(band risk.Band) riskBand {
+		return riskBand{Safe: band.Safe, Warning: band.Warning}
+	}
+	complexity := riskBand{
+		Safe:          b.riskThresholds.CyclomaticComplexity.Safe,
+		Warning:       b.riskThresholds.CyclomaticComplexity.Warning,
+		LowerIsBetter: true,
+	}
+	return map[string]riskBand{
+		"lineCoverage":            coverage(b.riskThresholds.LineCoverage),
+		"branchCoverage":          coverage(b.riskThresholds.BranchCoverage),
+		"methodBranchCoverage":    coverage(b.riskThresholds.BranchCoverage),
+		"methodsCovered":          coverage(b.riskThresholds.MethodCoverage),
+		"methodsFullyCovered":     coverage(b.riskThresholds.MethodCoverage),
+		"cyclomaticComplexity":    complexity,
+		"maxCyclomaticComplexity": complexity,
+	}
+}
+
+// gateIndex groups quality gate results by the path they were checked for.
+// The results for the whole project are stored under "".
+type gateIndex map[string][]model.GateResult
+
+func newGateIndex(results []model.GateResult) gateIndex {
+	gates := make(gateIndex)
+	for _, result := range results {
+		gates[result.Path] = append(gates[result.Path], result)
 	}
-	if percentage >= 60 {
-		return RiskWarning
+	return gates
+}
+
+// gateStatusKeys maps a gate metric to the status it decides.
+var gateStatusKeys = map[model.GateMetric]string{
+	model.GateLineCoverage:   "lineCoverage",
+	model.GateBranchCoverage: "branchCoverage",
+	model.GateMethodCoverage: "methodsCovered",
+}
+
+// applyGateStatuses marks every metric that failed a quality gate as danger.
+// Metrics that passed keep the status of their configured risk band.
+func applyGateStatuses(nodeStatuses statuses, results []model.GateResult) {
+	for _, result := range results {
+		key := gateStatusKeys[result.Metric]
+		if _, shown := nodeStatuses[key]; !shown || !result.Measured() || result.Passed {
+			continue
+		}
+		nodeStatuses[key] = RiskDanger
 	}
-	return RiskDanger
 }
 
 func (b *HtmlReactReportBuilder) buildMetricsMap(m model.CoverageMetrics) (metricsMap, statuses) {
@@ -206,7 +344,7 @@ func (b *HtmlReactReportBuilder) buildMetricsMap(m model.CoverageMetrics) (metri
 	}
 
 	nodeStatuses := statuses{
-		"lineCoverage": getRiskStatus(linePct),
+		"lineCoverage": coverageStatus(b.riskThresholds.LineCoverage, linePct),
 	}
 
 	if m.BranchesValid > 0 {
@@ -220,7 +358,7 @@ func (b *HtmlReactReportBuilder) buildMetricsMap(m model.CoverageMetrics) (metri
 			Total:      m.BranchesValid,
 			Percentage: branchPct,
 		}
-		nodeStatuses["branchCoverage"] = getRiskStatus(branchPct)
+		nodeStatuses["branchCoverage"] = coverageStatus(b.riskThresholds.BranchCoverage, branchPct)
 	}
 
 	if m.MethodsValid > 0 {
@@ -234,7 +372,7 @@ func (b *HtmlReactReportBuilder) buildMetricsMap(m model.CoverageMetrics) (metri
 			Total:      m.MethodsValid,
 			Percentage: methodsCoveredPct,
 		}
-		nodeStatuses["methodsCovered"] = getRiskStatus(methodsCoveredPct)
+		nodeStatuses["methodsCovered"] = coverageStatus(b.riskThresholds.MethodCoverage, methodsCoveredPct)
 
 		methodsFullyCoveredPct := utils.CalculatePercentage(m.MethodsFullyCovered, m.MethodsValid, 2)
 		if math.IsNaN(methodsFullyCoveredPct) {
@@ -246,7 +384,7 @@ func (b *HtmlReactReportBuilder) buildMetricsMap(m model.CoverageMetrics) (metri
 			Total:      m.MethodsValid,
 			Percentage: methodsFullyCoveredPct,
 		}
-		nodeStatuses["methodsFullyCovered"] = getRiskStatus(methodsFullyCoveredPct)
+		nodeStatuses["methodsFullyCovered"] = coverageStatus(b.riskThresholds.MethodCoverage, methodsFullyCoveredPct)
 	}
 
 	return metrics, nodeStatuses
@@ -11,7 +11,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/utils"
 	"golang.org/x/net/html"
@@ -27,8 +26,9 @@ func generateDetailsPages(b *HtmlReactReportBuilder, tree *model.SummaryTree) er
 		return err
 	}
 
+	gates := newGateIndex(tree.GateResults)
 	for _, fileNode := range fileNodeMap {
-		if err := b.createDetailPage(fileNode, detailsHTMLContent, tree); err != nil {
+		if err := b.createDetailPage(fileNode, detailsHTMLContent, tree, gates); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: could not generate details page for '%s': %v\n", fileNode.Path, err)
 		}
 	}
@@ -37,9 +37,9 @@ func generateDetailsPages(b *HtmlReactReportBuilder, tree *model.SummaryTree) er
 }
 
 // createDetailPage generates a single HTML file with coverage details for a given file node.
-func (b *HtmlReactReportBuilder) createDetailPage(fileNode *model.FileNode, detailsHTMLContent []byte, tree *model.SummaryTree) error {
+func (b *HtmlReactReportBuilder) createDetailPage(fileNode *model.FileNode, detailsHTMLContent []byte, tree *model.SummaryTree, gates gateIndex) error {
 	// Transform the file node data into the format required by the UI.
-	detailsData, err := b.transformFileNodeToDetails(fileNode, tree)
+	detailsData, err := b.transformFileNodeToDetails(fileNode, tree, gates)
 	if err != nil {
 		return fmt.Errorf("failed to transform file node data: %w", err)
 	}
@@ -64,17 +64,16 @@ func (b *HtmlReactReportBuilder) createDetailPage(fileNode *model.FileNode, deta
 }
 
 // transformFileNodeToDetails converts a model.FileNode into the rich detailsV1 structure.
-func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.FileNode, tree *model.SummaryTree) (*detailsV1, error) {
-	reader := filereader.NewDefaultReader()
-	absPath, err := utils.FindFileInSourceDirs(fileNode.Path, []string{fileNode.SourceDir}, reader, b.logger)
+func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.FileNode, tree *model.SummaryTree, gates gateIndex) (*detailsV1, error) {
 	var sourceLines []string
-	if err == nil {
-		sourceLines, err = reader.ReadFile(absPath)
+	if fileNode.AbsolutePath != "" {
+		var err error
+		sourceLines, err = b.fileReader.ReadFile(fileNode.AbsolutePath)
 		if err != nil {
-			b.logger.Warn("Could not read source file for details page", "file", absPath, "error", err)
+			b.logger.Warn("Could not read source file for details page", "file", fileNode.AbsolutePath, "error", err)
 		}
 	} else {
-		b.logger.Warn("Could not find source file for details page", "file", fileNode.Path, "error", err)
+		b.logger.Warn("Could not find source file for details page", "file", fileNode.Path)
 	}
 
 	// Find which report indices are relevant for this specific file, FIRST.
@@ -94,6 +93,15 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 	}
 	sort.Ints(sortedIndices)
 
+	// Reports in "set" mode only record whether a line ran, so their hits are
+	// not shown as counts unless another report counted executions.
+	showHits := false
+	for _, reportIndex := range sortedIndices {
+		if reportIndex >= len(tree.ReportModes) || tree.ReportModes[reportIndex] != "set" {
+			showHits = true
+		}
+	}
+
 	// Build the final list of reports for the UI using only the relevant ones.
 	var reportsList []report
 	for _, reportIndex := range sortedIndices {
@@ -113,7 +121,7 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 		if hasMetric && lineMetric.Hits >= 0 {
 			// Create the dense hits array that corresponds to the filtered reportsList
 			denseHits := make([]int, 0, len(sortedIndices))
-			if lineMetric.ReportHits != nil {
+			if lineMetric.ReportHits != nil && showHits {
 				for _, reportIndex := range sortedIndices {
 					denseHits = append(denseHits, lineMetric.ReportHits[reportIndex])
 				}
@@ -131,6 +139,12 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 					ld.Status = StatusPartial
 				}
 			}
+			if lineMetric.CoveredStatements > 0 && lineMetric.CoveredStatements < lineMetric.TotalStatements {
+				ld.Status = StatusPartial
+			}
+			if lineMetric.Hits > 0 && lineMetric.UnexecutedBlock {
+				ld.Status = StatusPartial
+			}
 		}
 		detailsLines[i] = ld
 	}
@@ -150,7 +164,7 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 		}
 
 		lineMetric := methodMetric{Value: utils.FormatPercentage(lineCovPct, 0)}
-		lineRisk := getRiskStatus(lineCovPct)
+		lineRisk := coverageStatus(b.riskThresholds.LineCoverage, lineCovPct)
 		if lineRisk == RiskDanger || lineRisk == RiskWarning {
 			lineMetric.Status = lineRisk
 		}
@@ -158,7 +172,7 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 
 		if method.BranchesValid > 0 {
 			branchMetric := methodMetric{Value: utils.FormatPercentage(branchCovPct, 0)}
-			branchRisk := getRiskStatus(branchCovPct)
+			branchRisk := coverageStatus(b.riskThresholds.BranchCoverage, branchCovPct)
 			if branchRisk == RiskDanger || branchRisk == RiskWarning {
 				branchMetric.Status = branchRisk
 			}
@@ -166,7 +180,12 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 		}
 
 		if method.CyclomaticComplexity != nil {
-			md.Metrics["cyclomaticComplexity"] = methodMetric{Value: fmt.Sprintf("%d", *method.CyclomaticComplexity)}
+			complexityMetric := methodMetric{Value: fmt.Sprintf("%d", *method.CyclomaticComplexity)}
+			complexityRisk := complexityStatus(b.riskThresholds.CyclomaticComplexity, *method.CyclomaticComplexity)
+			if complexityRisk == RiskDanger || complexityRisk == RiskWarning {
+				complexityMetric.Status = complexityRisk
+			}
+			md.Metrics["cyclomaticComplexity"] = complexityMetric
 		}
 		detailsMethods = append(detailsMethods, md)
 
@@ -180,6 +199,7 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 	sort.Slice(detailsMethods, func(i, j int) bool { return detailsMethods[i].StartLine < detailsMethods[j].StartLine })
 
 	fileMetrics, fileStatuses := b.buildMetricsMap(fileNode.Metrics)
+	applyGateStatuses(fileStatuses, gates[fileNode.Path])
 	totalsData := totals{Files: 1, Folders: 0, Statuses: fileStatuses}
 	if lc, ok := fileMetrics["lineCoverage"].(lineCoverageDetail); ok {
 		totalsData.LineCoverage = &lc
@@ -202,7 +222,7 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 			Total:      totalMethodBranches,
 			Percentage: methodBranchPct,
 		}
-		fileStatuses["methodBranchCoverage"] = getRiskStatus(methodBranchPct)
+		fileStatuses["methodBranchCoverage"] = coverageStatus(b.riskThresholds.BranchCoverage, methodBranchPct)
 	}
 
 	if maxCyclo > 0 {
@@ -212,6 +232,7 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 			Total:      maxCyclo,
 			Percentage: 0,
 		}
+		fileStatuses["maxCyclomaticComplexity"] = complexityStatus(b.riskThresholds.CyclomaticComplexity, maxCyclo)
 	}
 
 	return &detailsV1{
@@ -222,6 +243,7 @@ func (b *HtmlReactReportBuilder) transformFileNodeToDetails(fileNode *model.File
 		Metadata:          []metadataItem{},
 		Totals:            totalsData,
 		MetricDefinitions: b.buildMetricDefinitions(),
+		RiskThresholds:    b.buildRiskThresholds(),
 		Methods:           detailsMethods,
 		Lines:             detailsLines,
 		Reports:           reportsList,
@@ -85,15 +85,54 @@ type metricDefinition struct {
 
 type metricDefinitions map[string]metricDefinition
 
+// riskBand is the configured band of a metric. For coverage, values of at
+// least Safe are safe and values of at least Warning are warnings; with
+// LowerIsBetter (complexity) the comparisons are reversed.
+type riskBand struct {
+	Safe          float64 `json:"safe"`
+	Warning       float64 `json:"warning"`
+	LowerIsBetter bool    `json:"lowerIsBetter,omitempty"`
+}
+
+// historyPoint is the coverage of an earlier run, or of the current one, for
+// trend charts. Metrics that were not measured are omitted.
+type historyPoint struct {
+	Tag            string   `json:"tag,omitempty"`
+	Timestamp      string   `json:"timestamp"`
+	Commit         string   `json:"commit,omitempty"`
+	LineCoverage   *float64 `json:"lineCoverage,omitempty"`
+	BranchCoverage *float64 `json:"branchCoverage,omitempty"`
+	MethodCoverage *float64 `json:"methodCoverage,omitempty"`
+}
+
+// diagnosticGroup lists the report files of one kind of diagnostic, e.g. the
+// files that could not be resolved.
+type diagnosticGroup struct {
+	Kind  string           `json:"kind"`
+	Title string           `json:"title"`
+	Files []diagnosticFile `json:"files"`
+}
+
+type diagnosticFile struct {
+	Path         string   `json:"path"`
+	Report       string   `json:"report"`
+	ResolvedPath string   `json:"resolvedPath,omitempty"`
+	Candidates   []string `json:"candidates,omitempty"`
+	Detail       string   `json:"detail,omitempty"`
+}
+
 type summaryV1 struct {
-	SchemaVersion     int               `json:"schemaVersion"`
-	GeneratedAt       string            `json:"generatedAt"`
-	ReportID          string            `json:"reportId,omitempty"`
-	Title             string            `json:"title"`
-	Totals            totals            `json:"totals"`
-	Tree              []fileNode        `json:"tree"`
-	MetricDefinitions metricDefinitions `json:"metricDefinitions"`
-	Metadata          []metadataItem    `json:"metadata,omitempty"`
+	SchemaVersion     int                 `json:"schemaVersion"`
+	GeneratedAt       string              `json:"generatedAt"`
+	ReportID          string              `json:"reportId,omitempty"`
+	Title             string              `json:"title"`
+	Totals            totals              `json:"totals"`
+	Tree              []fileNode          `json:"tree"`
+	MetricDefinitions metricDefinitions   `json:"metricDefinitions"`
+	RiskThresholds    map[string]riskBand `json:"riskThresholds,omitempty"`
+	Metadata          []metadataItem      `json:"metadata,omitempty"`
+	History           []historyPoint      `json:"history,omitempty"`
+	Diagnostics       []diagnosticGroup   `json:"diagnostics,omitempty"`
 }
 
 type lineStatus string
@@ -136,14 +175,15 @@ type report struct {
 }
 
 type detailsV1 struct {
-	SchemaVersion     int               `json:"schemaVersion"`
-	GeneratedAt       string            `json:"generatedAt"`
-	Title             string            `json:"title"`
-	FileName          string            `json:"fileName"`
-	Metadata          []metadataItem    `json:"metadata"`
-	Totals            totals            `json:"totals"`
-	MetricDefinitions metricDefinitions `json:"metricDefinitions"`
-	Methods           []methodDetail    `json:"methods,omitempty"`
-	Lines             []lineDetail      `json:"lines"`
-	Reports           []report          `json:"reports,omitempty"`
+	SchemaVersion     int                 `json:"schemaVersion"`
+	GeneratedAt       string              `json:"generatedAt"`
+	Title             string              `json:"title"`
+	FileName          string              `json:"fileName"`
+	Metadata          []metadataItem      `json:"metadata"`
+	Totals            totals              `json:"totals"`
+	MetricDefinitions metricDefinitions   `json:"metricDefinitions"`
+	RiskThresholds    map[string]riskBand `json:"riskThresholds,omitempty"`
+	Methods           []methodDetail      `json:"methods,omitempty"`
+	Lines             []lineDetail        `json:"lines"`
+	Reports           []report            `json:"reports,omitempty"`
 }
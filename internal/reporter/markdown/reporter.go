@@ -0,0 +1,334 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter"
+	"github.com/IgorBayerl/nanovision/internal/risk"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// maxFileRows caps the file table, so the comment stays below the size
+// limits of pull request comments on large projects.
+const maxFileRows = 250
+
+// Flavor is the markdown dialect of the platform the summary is posted to.
+// GitHub and GitLab both render collapsible sections and emoji shortcodes,
+// but do not know the same shortcodes.
+type Flavor string
+
+const (
+	GitHub Flavor = "github"
+	GitLab Flavor = "gitlab"
+)
+
+// Validate checks that the flavor is one the summary can be written in.
+func (f Flavor) Validate() error {
+	if _, ok := dialects[f]; !ok {
+		return fmt.Errorf("unknown markdown flavor %q, expected %q or %q", f, GitHub, GitLab)
+	}
+	return nil
+}
+
+// dialect holds what a flavor writes differently.
+type dialect struct {
+	levelMarkers     map[risk.Level]string // The emoji shortcodes of the risk levels.
+	unmeasuredMarker string
+	openDetails      string // Starts a collapsible section, with a %s for its summary.
+	closeDetails     string
+}
+
+var dialects = map[Flavor]dialect{
+	GitHub: {
+		levelMarkers: map[risk.Level]string{
+			risk.Safe:    ":green_circle:",
+			risk.Warning: ":yellow_circle:",
+			risk.Danger:  ":red_circle:",
+		},
+		unmeasuredMarker: ":white_circle:",
+		openDetails:      "<details>\n<summary><b>%s</b></summary>\n\n",
+		closeDetails:     "\n</details>\n",
+	},
+	// GitLab's emoji set has no green or yellow circle, and its collapsible
+	// sections are written as in its documentation, with a plain summary on
+	// the line of the opening tag.
+	GitLab: {
+		levelMarkers: map[risk.Level]string{
+			risk.Safe:    ":white_check_mark:",
+			risk.Warning: ":warning:",
+			risk.Danger:  ":x:",
+		},
+		unmeasuredMarker: ":grey_question:",
+		openDetails:      "<details><summary>%s</summary>\n\n",
+		closeDetails:     "\n</details>\n",
+	},
+}
+
+// MarkdownReportBuilder writes a summary meant to be posted as a pull or
+// merge request comment: the project coverage and its change against the
+// baseline, the patch coverage, a collapsible table of the files and a
+// warning for the changed files whose patch coverage is low.
+type MarkdownReportBuilder struct {
+	outputDir      string
+	dialect        dialect
+	riskThresholds risk.Thresholds
+	logger         *slog.Logger
+}
+
+// NewMarkdownReportBuilder creates a builder writing in the given flavor. An
+// unknown flavor falls back to GitHub's; see Flavor.Validate.
+func NewMarkdownReportBuilder(outputDir string, flavor Flavor, riskThresholds risk.Thresholds, logger *slog.Logger) reporter.ReportBuilder {
+	d, ok := dialects[flavor]
+	if !ok {
+		d = dialects[GitHub]
+	}
+	return &MarkdownReportBuilder{
+		outputDir:      outputDir,
+		dialect:        d,
+		riskThresholds: riskThresholds,
+		logger:         logger,
+	}
+}
+
+func (b *MarkdownReportBuilder) ReportType() string {
+	return "Markdown"
+}
+
+func (b *MarkdownReportBuilder) CreateReport(tree *model.SummaryTree) error {
+	outputPath := filepath.Join(b.outputDir, "Summary.md")
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close()
+
+	b.logger.Info("Writing markdown summary to file", "path", outputPath)
+
+	if err := b.writeSummary(f, tree); err != nil {
+		return fmt.Errorf("failed to write markdown summary to '%s': %w", outputPath, err)
+	}
+	return nil
+}
+
+func (b *MarkdownReportBuilder) writeSummary(w io.Writer, tree *model.SummaryTree) error {
+	var sb strings.Builder
+	sb.WriteString("## Code Coverage Report\n\n")
+
+	if tree.Patch != nil {
+		patchCoverage := utils.CalculatePercentage(tree.Patch.Metrics.LinesCovered, tree.Patch.Metrics.LinesValid, 2)
+		fmt.Fprintf(&sb, ":rocket: **Patch Coverage:** `%s`\n", utils.FormatPercentage(patchCoverage, 2))
+	}
+
+	projectCoverage := utils.CalculatePercentage(tree.Metrics.LinesCovered, tree.Metrics.LinesValid, 2)
+	projectDelta := math.NaN()
+	if tree.Baseline != nil {
+		baselineCoverage := utils.CalculatePercentage(tree.Baseline.Metrics.LinesCovered, tree.Baseline.Metrics.LinesValid, 2)
+		projectDelta = projectCoverage - baselineCoverage
+	}
+	switch {
+	case math.IsNaN(projectDelta):
+		fmt.Fprintf(&sb, ":bar_chart: **Project Coverage:** `%s`\n", utils.FormatPercentage(projectCoverage, 2))
+	case projectDelta < 0:
+		fmt.Fprintf(&sb, ":chart_with_downwards_trend: **Project Coverage:** `%s` (`%s`)\n", utils.FormatPercentage(projectCoverage, 2), utils.FormatDelta(projectDelta, 2))
+	default:
+		fmt.Fprintf(&sb, ":chart_with_upwards_trend: **Project Coverage:** `%s` (`%s`)\n", utils.FormatPercentage(projectCoverage, 2), utils.FormatDelta(projectDelta, 2))
+	}
+
+	sb.WriteString("\n---\n\n")
+	fmt.Fprintf(&sb, b.dialect.openDetails, "View Full File-by-File Coverage Report")
+	b.writeFileTable(&sb, tree)
+	sb.WriteString(b.dialect.closeDetails)
+	sb.WriteString("\n---\n\n")
+
+	if lowFiles := b.lowPatchCoverageFiles(tree.Patch); len(lowFiles) > 0 {
+		sb.WriteString("> :warning: ")
+		switch {
+		case projectDelta > 0:
+			sb.WriteString("While overall coverage is increasing, the")
+		case projectDelta < 0:
+			sb.WriteString("Overall coverage is decreasing, and the")
+		default:
+			sb.WriteString("The")
+		}
+		fmt.Fprintf(&sb, " **patch coverage** on %s is low. Please add tests for the new logic.\n\n", joinCodeList(lowFiles))
+	}
+
+	sb.WriteString("*Report generated by [nanovision](https://github.com/IgorBayerl/nanovision)*\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeFileTable lists the changed files when patch coverage was computed,
+// and every file of the project otherwise.
+func (b *MarkdownReportBuilder) writeFileTable(sb *strings.Builder, tree *model.SummaryTree) {
+	files := tree.FilesByPath()
+	var baselineFiles map[string]*model.FileNode
+	if tree.Baseline != nil {
+		baselineFiles = tree.Baseline.FilesByPath()
+	}
+
+	rows := [][]string{{"File", "Line Coverage", "Patch Coverage", "Complexity"}}
+	if tree.Patch != nil {
+		for _, filePatch := range tree.Patch.Files {
+			var fileNode *model.FileNode
+			if filePatch.InReport {
+				fileNode = files[filePatch.Path]
+			}
+			rows = append(rows, b.fileRow(filePatch.Path, fileNode, baselineFiles[filePatch.Path], &filePatch.Metrics))
+		}
+	} else {
+		for _, path := range sortedKeys(files) {
+			rows = append(rows, b.fileRow(path, files[path], baselineFiles[path], nil))
+		}
+	}
+
+	omitted := 0
+	if len(rows)-1 > maxFileRows {
+		omitted = len(rows) - 1 - maxFileRows
+		rows = rows[:maxFileRows+1]
+	}
+	writeTable(sb, rows)
+	if omitted > 0 {
+		fmt.Fprintf(sb, "\n*... and %d more files.*\n", omitted)
+	}
+}
+
+// fileRow renders a file of the table. Its marker is the worse of the risk
+// levels of its line and patch coverage, or white when neither was measured.
+func (b *MarkdownReportBuilder) fileRow(path string, fileNode, baselineNode *model.FileNode, patchMetrics *model.PatchMetrics) []string {
+	marker := b.dialect.unmeasuredMarker
+	var levels []risk.Level
+	lineCell, patchCell, complexityCell := "`N/A`", "`N/A`", "N/A"
+
+	if fileNode != nil && fileNode.Metrics.LinesValid > 0 {
+		lineCoverage := utils.CalculatePercentage(fileNode.Metrics.LinesCovered, fileNode.Metrics.LinesValid, 1)
+		levels = append(levels, b.riskThresholds.LineCoverage.Coverage(lineCoverage))
+		lineCell = fmt.Sprintf("`%s`", utils.FormatPercentage(lineCoverage, 1))
+		if baselineNode != nil && baselineNode.Metrics.LinesValid > 0 {
+			baselineCoverage := utils.CalculatePercentage(baselineNode.Metrics.LinesCovered, baselineNode.Metrics.LinesValid, 1)
+			lineCell += fmt.Sprintf(" (`%s`)", utils.FormatDelta(lineCoverage-baselineCoverage, 1))
+		}
+	}
+	if patchMetrics != nil && patchMetrics.LinesValid > 0 {
+		patchCoverage := utils.CalculatePercentage(patchMetrics.LinesCovered, patchMetrics.LinesValid, 1)
+		levels = append(levels, b.riskThresholds.LineCoverage.Coverage(patchCoverage))
+		patchCell = fmt.Sprintf("`%s`", utils.FormatPercentage(patchCoverage, 1))
+	}
+	if fileNode != nil {
+		if complexity, ok := averageComplexity(fileNode.Methods); ok {
+			complexityCell = fmt.Sprintf("%.1f", complexity)
+		}
+	}
+
+	if len(levels) > 0 {
+		marker = b.dialect.levelMarkers[worstLevel(levels)]
+	}
+	fileCell := fmt.Sprintf("%s `%s`", marker, strings.ReplaceAll(path, "|", "\\|"))
+	return []string{fileCell, lineCell, patchCell, complexityCell}
+}
+
+// lowPatchCoverageFiles returns the changed files whose patch coverage falls
+// in the danger band of line coverage.
+func (b *MarkdownReportBuilder) lowPatchCoverageFiles(patch *model.PatchCoverage) []string {
+	if patch == nil {
+		return nil
+	}
+	var paths []string
+	for _, filePatch := range patch.Files {
+		if filePatch.Metrics.LinesValid == 0 {
+			continue
+		}
+		patchCoverage := utils.CalculatePercentage(filePatch.Metrics.LinesCovered, filePatch.Metrics.LinesValid, 1)
+		if b.riskThresholds.LineCoverage.Coverage(patchCoverage) == risk.Danger {
+			paths = append(paths, filePatch.Path)
+		}
+	}
+	return paths
+}
+
+// writeTable writes rows as a markdown table whose columns are padded to the
+// same width, so the raw comment is readable as well. The first row is the
+// header.
+func writeTable(sb *strings.Builder, rows [][]string) {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	writeRow := func(row []string) {
+		for i, cell := range row {
+			fmt.Fprintf(sb, "| %s%s ", cell, strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+		sb.WriteString("|\n")
+	}
+
+	writeRow(rows[0])
+	for _, width := range widths {
+		sb.WriteString("|" + strings.Repeat("-", width+2))
+	}
+	sb.WriteString("|\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+}
+
+// averageComplexity returns the mean cyclomatic complexity of the methods
+// that have one.
+func averageComplexity(methods []model.MethodMetrics) (float64, bool) {
+	total, count := 0, 0
+	for _, method := range methods {
+		if method.CyclomaticComplexity != nil {
+			total += *method.CyclomaticComplexity
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return float64(total) / float64(count), true
+}
+
+func worstLevel(levels []risk.Level) risk.Level {
+	rank := map[risk.Level]int{risk.Safe: 0, risk.Warning: 1, risk.Danger: 2}
+	worst := risk.Safe
+	for _, level := range levels {
+		if rank[level] > rank[worst] {
+			worst = level
+		}
+	}
+	return worst
+}
+
+// joinCodeList formats paths as code spans in a sentence: "`a`", "`a` and
+// `b`" or "`a`, `b` and `c`".
+func joinCodeList(paths []string) string {
+	quoted := make([]string, len(paths))
+	for i, path := range paths {
+		quoted[i] = "`" + path + "`"
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " and " + quoted[len(quoted)-1]
+}
+
+func sortedKeys(files map[string]*model.FileNode) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
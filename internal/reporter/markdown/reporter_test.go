@@ -0,0 +1,213 @@
+package markdown_test
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter/markdown"
+	"github.com/IgorBayerl/nanovision/internal/risk"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFile builds a file with the given covered and valid lines and method
+// complexities.
+func newFile(path string, covered, valid int, complexities ...int) *model.FileNode {
+	file := testutil.NewFile(path)
+	file.Metrics = model.CoverageMetrics{LinesCovered: covered, LinesValid: valid}
+	for _, complexity := range complexities {
+		file.Methods = append(file.Methods, model.MethodMetrics{CyclomaticComplexity: &complexity})
+	}
+	return file
+}
+
+func filePatch(path string, covered, valid int) model.FilePatchCoverage {
+	return model.FilePatchCoverage{
+		Path:     path,
+		InReport: valid > 0,
+		Metrics:  model.PatchMetrics{LinesChanged: valid, LinesValid: valid, LinesCovered: covered},
+	}
+}
+
+func TestMarkdownReportBuilder_CreateReport(t *testing.T) {
+	testCases := []struct {
+		name     string
+		flavor   markdown.Flavor
+		tree     func() *model.SummaryTree
+		expected string
+	}{
+		{
+			name:   "Patch and baseline",
+			flavor: markdown.GitHub,
+			tree: func() *model.SummaryTree {
+				// The project totals include files the table does not list.
+				tree := testutil.NewTree(
+					newFile("src/utils/parser.go", 197, 200, 1, 2, 2, 2, 2),
+					newFile("src/handlers/user.go", 91, 100, 3, 3, 3, 4, 3),
+					newFile("src/models/payment.go", 75, 100, 8, 9),
+				)
+				tree.Metrics = model.CoverageMetrics{LinesCovered: 171, LinesValid: 200}
+				tree.Baseline = testutil.NewTree(
+					newFile("src/utils/parser.go", 193, 200),
+					newFile("src/handlers/user.go", 183, 200),
+					newFile("src/models/payment.go", 85, 100),
+				)
+				tree.Baseline.Metrics = model.CoverageMetrics{LinesCovered: 844, LinesValid: 1000}
+				tree.Patch = &model.PatchCoverage{
+					Metrics: model.PatchMetrics{LinesValid: 51, LinesCovered: 47},
+					Files: []model.FilePatchCoverage{
+						filePatch("src/utils/parser.go", 10, 10),
+						filePatch("src/handlers/user.go", 19, 20),
+						filePatch("src/models/payment.go", 91, 200),
+						filePatch("README.md", 0, 0),
+					},
+				}
+				return tree
+			},
+			expected: "## Code Coverage Report\n" +
+				"\n" +
+				":rocket: **Patch Coverage:** `92.15%`\n" +
+				":chart_with_upwards_trend: **Project Coverage:** `85.50%` (`+1.10%`)\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary><b>View Full File-by-File Coverage Report</b></summary>\n" +
+				"\n" +
+				"| File                                  | Line Coverage      | Patch Coverage | Complexity |\n" +
+				"|---------------------------------------|--------------------|----------------|------------|\n" +
+				"| :green_circle: `src/utils/parser.go`  | `98.5%` (`+2.0%`)  | `100.0%`       | 1.8        |\n" +
+				"| :green_circle: `src/handlers/user.go` | `91.0%` (`-0.5%`)  | `95.0%`        | 3.2        |\n" +
+				"| :red_circle: `src/models/payment.go`  | `75.0%` (`-10.0%`) | `45.5%`        | 8.5        |\n" +
+				"| :white_circle: `README.md`            | `N/A`              | `N/A`          | N/A        |\n" +
+				"\n" +
+				"</details>\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"> :warning: While overall coverage is increasing, the **patch coverage** on `src/models/payment.go` is low. Please add tests for the new logic.\n" +
+				"\n" +
+				"*Report generated by [nanovision](https://github.com/IgorBayerl/nanovision)*\n",
+		},
+		{
+			name:   "Without patch or baseline every file is listed",
+			flavor: markdown.GitHub,
+			tree: func() *model.SummaryTree {
+				return testutil.NewTree(
+					newFile("b.go", 1, 4),
+					newFile("a.go", 2, 3, 5),
+					newFile("c.go", 0, 3),
+				)
+			},
+			expected: "## Code Coverage Report\n" +
+				"\n" +
+				":bar_chart: **Project Coverage:** `30.00%`\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary><b>View Full File-by-File Coverage Report</b></summary>\n" +
+				"\n" +
+				"| File                   | Line Coverage | Patch Coverage | Complexity |\n" +
+				"|------------------------|---------------|----------------|------------|\n" +
+				"| :yellow_circle: `a.go` | `66.6%`       | `N/A`          | 5.0        |\n" +
+				"| :red_circle: `b.go`    | `25.0%`       | `N/A`          | N/A        |\n" +
+				"| :red_circle: `c.go`    | `0.0%`        | `N/A`          | N/A        |\n" +
+				"\n" +
+				"</details>\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"*Report generated by [nanovision](https://github.com/IgorBayerl/nanovision)*\n",
+		},
+		{
+			name:   "Low patch coverage while coverage drops",
+			flavor: markdown.GitHub,
+			tree: func() *model.SummaryTree {
+				tree := testutil.NewTree(newFile("a.go", 1, 2), newFile("b.go", 0, 0))
+				tree.Baseline = testutil.NewTree(newFile("a.go", 3, 4))
+				tree.Patch = &model.PatchCoverage{
+					Metrics: model.PatchMetrics{LinesValid: 4, LinesCovered: 1},
+					Files:   []model.FilePatchCoverage{filePatch("a.go", 1, 2), filePatch("b.go", 0, 2)},
+				}
+				return tree
+			},
+			expected: "## Code Coverage Report\n" +
+				"\n" +
+				":rocket: **Patch Coverage:** `25.00%`\n" +
+				":chart_with_downwards_trend: **Project Coverage:** `50.00%` (`-25.00%`)\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"<details>\n" +
+				"<summary><b>View Full File-by-File Coverage Report</b></summary>\n" +
+				"\n" +
+				"| File                | Line Coverage      | Patch Coverage | Complexity |\n" +
+				"|---------------------|--------------------|----------------|------------|\n" +
+				"| :red_circle: `a.go` | `50.0%` (`-25.0%`) | `50.0%`        | N/A        |\n" +
+				"| :red_circle: `b.go` | `N/A`              | `0.0%`         | N/A        |\n" +
+				"\n" +
+				"</details>\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"> :warning: Overall coverage is decreasing, and the **patch coverage** on `a.go` and `b.go` is low. Please add tests for the new logic.\n" +
+				"\n" +
+				"*Report generated by [nanovision](https://github.com/IgorBayerl/nanovision)*\n",
+		},
+		{
+			name:   "GitLab flavor",
+			flavor: markdown.GitLab,
+			tree: func() *model.SummaryTree {
+				return testutil.NewTree(newFile("a.go", 1, 2), newFile("b.go", 3, 4), newFile("c.go", 4, 4), newFile("d.go", 0, 0))
+			},
+			expected: "## Code Coverage Report\n" +
+				"\n" +
+				":bar_chart: **Project Coverage:** `80.00%`\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"<details><summary>View Full File-by-File Coverage Report</summary>\n" +
+				"\n" +
+				"| File                      | Line Coverage | Patch Coverage | Complexity |\n" +
+				"|---------------------------|---------------|----------------|------------|\n" +
+				"| :x: `a.go`                | `50.0%`       | `N/A`          | N/A        |\n" +
+				"| :warning: `b.go`          | `75.0%`       | `N/A`          | N/A        |\n" +
+				"| :white_check_mark: `c.go` | `100.0%`      | `N/A`          | N/A        |\n" +
+				"| :grey_question: `d.go`    | `N/A`         | `N/A`          | N/A        |\n" +
+				"\n" +
+				"</details>\n" +
+				"\n" +
+				"---\n" +
+				"\n" +
+				"*Report generated by [nanovision](https://github.com/IgorBayerl/nanovision)*\n",
+		},
+	}
+
+	nopLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			builder := markdown.NewMarkdownReportBuilder(tmpDir, tc.flavor, risk.DefaultThresholds(), nopLogger)
+
+			err := builder.CreateReport(tc.tree())
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(tmpDir, "Summary.md"))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(content))
+		})
+	}
+}
+
+func TestFlavor_Validate(t *testing.T) {
+	assert.NoError(t, markdown.GitHub.Validate())
+	assert.NoError(t, markdown.GitLab.Validate())
+	assert.Error(t, markdown.Flavor("bitbucket").Validate())
+}
@@ -42,3 +42,33 @@ func (b *RawJsonReportBuilder) CreateReport(tree *model.SummaryTree) error {
 
 	return nil
 }
+
+// LoadSummaryTree reads a tree written by the RawJson report, e.g. by an
+// earlier run on the target branch. Parent links are not serialized, so they
+// are restored.
+func LoadSummaryTree(path string) (*model.SummaryTree, error) {
+	jsonData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON summary report '%s': %w", path, err)
+	}
+
+	var tree model.SummaryTree
+	if err := json.Unmarshal(jsonData, &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON summary report '%s': %w", path, err)
+	}
+	if tree.Root == nil {
+		return nil, fmt.Errorf("JSON summary report '%s' has no root directory", path)
+	}
+	linkParents(tree.Root)
+	return &tree, nil
+}
+
+func linkParents(dir *model.DirNode) {
+	for _, file := range dir.Files {
+		file.Parent = dir
+	}
+	for _, subdir := range dir.Subdirs {
+		subdir.Parent = dir
+		linkParents(subdir)
+	}
+}
@@ -2,13 +2,16 @@ package reporter_rawjson_test
 
 import (
 	"encoding/json"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/qualitygate"
 	"github.com/IgorBayerl/nanovision/internal/reporter/reporter_rawjson"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -109,3 +112,90 @@ func TestRawJsonReportBuilder_CreateReport(t *testing.T) {
 	assert.NotNil(t, actualTree.Root.Subdirs["subdir"].Files["file2.go"].Methods[0].CyclomaticComplexity)
 	assert.Equal(t, 1, *actualTree.Root.Subdirs["subdir"].Files["file2.go"].Methods[0].CyclomaticComplexity)
 }
+
+func TestLoadSummaryTree_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	rootNode := &model.DirNode{Name: "Root", Path: ".", Subdirs: make(map[string]*model.DirNode)}
+	subdirNode := &model.DirNode{Name: "pkg", Path: "pkg", Parent: rootNode, Files: make(map[string]*model.FileNode)}
+	rootNode.Subdirs["pkg"] = subdirNode
+	subdirNode.Files["a.go"] = &model.FileNode{
+		Name:    "a.go",
+		Path:    "pkg/a.go",
+		Parent:  subdirNode,
+		Metrics: model.CoverageMetrics{LinesCovered: 1, LinesValid: 2},
+		Lines:   map[int]model.LineMetrics{3: {Hits: 1}, 4: {Hits: 0}},
+	}
+	tree := &model.SummaryTree{
+		Root:     rootNode,
+		Metrics:  model.CoverageMetrics{LinesCovered: 1, LinesValid: 2},
+		Baseline: &model.SummaryTree{Root: &model.DirNode{Name: "Old"}},
+	}
+	require.NoError(t, reporter_rawjson.NewRawJsonReportBuilder(tmpDir).CreateReport(tree))
+
+	loaded, err := reporter_rawjson.LoadSummaryTree(filepath.Join(tmpDir, "RawJson.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, tree.Metrics, loaded.Metrics)
+	assert.Nil(t, loaded.Baseline, "Baselines should not be nested in saved trees")
+	loadedSubdir := loaded.Root.Subdirs["pkg"]
+	require.NotNil(t, loadedSubdir)
+	assert.Same(t, loaded.Root, loadedSubdir.Parent)
+	loadedFile := loadedSubdir.Files["a.go"]
+	require.NotNil(t, loadedFile)
+	assert.Same(t, loadedSubdir, loadedFile.Parent)
+	assert.Equal(t, 1, loadedFile.Lines[3].Hits)
+	assert.Contains(t, loaded.FilesByPath(), "pkg/a.go")
+}
+
+func TestRawJsonReportBuilder_CreateReport_UnmeasuredGates(t *testing.T) {
+	tmpDir := t.TempDir()
+	tree := &model.SummaryTree{
+		Root:    &model.DirNode{Name: "Root", Path: "."},
+		Metrics: model.CoverageMetrics{LinesCovered: 5, LinesValid: 10},
+		GateResults: []model.GateResult{
+			{Metric: model.GateLineCoverage, Minimum: 10, Actual: 50, Passed: true},
+			{Metric: model.GateBranchCoverage, Minimum: 50, Actual: math.NaN(), Passed: true},
+		},
+	}
+
+	require.NoError(t, reporter_rawjson.NewRawJsonReportBuilder(tmpDir).CreateReport(tree), "A gate with nothing to measure should not break the report")
+
+	loaded, err := reporter_rawjson.LoadSummaryTree(filepath.Join(tmpDir, "RawJson.json"))
+	require.NoError(t, err)
+	require.Len(t, loaded.GateResults, 2)
+	assert.True(t, loaded.GateResults[0].Measured())
+	assert.Equal(t, 50.0, loaded.GateResults[0].Actual)
+	assert.False(t, loaded.GateResults[1].Measured(), "An unmeasured gate should stay unmeasured once loaded")
+	assert.Equal(t, model.GateBranchCoverage, loaded.GateResults[1].Metric)
+}
+
+func TestRawJsonReportBuilder_CreateReport_PathRuleGates(t *testing.T) {
+	tmpDir := t.TempDir()
+	tree := testutil.NewTree(testutil.NewFile("pkg/a.go", 1, 0), testutil.NewFile("pkg/b.go", 1, 1))
+	rules, err := qualitygate.NewPathRules([]qualitygate.PathRule{{Path: "pkg/**", Line: 50, Branch: 50}})
+	require.NoError(t, err)
+	tree.GateResults = rules.EvaluateTree(tree.Root)
+	require.Len(t, tree.GateResults, 6, "Every directory and file matched by the rule has a line and a branch result")
+
+	require.NoError(t, reporter_rawjson.NewRawJsonReportBuilder(tmpDir).CreateReport(tree), "Files without branches should not break the report")
+
+	loaded, err := reporter_rawjson.LoadSummaryTree(filepath.Join(tmpDir, "RawJson.json"))
+	require.NoError(t, err)
+	require.Len(t, loaded.GateResults, len(tree.GateResults))
+	for i, result := range loaded.GateResults {
+		assert.Equal(t, tree.GateResults[i].String(), result.String())
+	}
+}
+
+func TestLoadSummaryTree_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+	invalidPath := filepath.Join(tmpDir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("{"), 0644))
+	emptyPath := filepath.Join(tmpDir, "empty.json")
+	require.NoError(t, os.WriteFile(emptyPath, []byte("{}"), 0644))
+
+	for _, path := range []string{filepath.Join(tmpDir, "missing.json"), invalidPath, emptyPath} {
+		_, err := reporter_rawjson.LoadSummaryTree(path)
+		assert.Error(t, err, path)
+	}
+}
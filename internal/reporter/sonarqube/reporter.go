@@ -0,0 +1,105 @@
+package sonarqube
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter"
+)
+
+// SonarQubeReportBuilder writes the merged tree in SonarQube's generic
+// coverage format, for languages and tools Sonar cannot import natively.
+// File paths are relative to the project root, which Sonar resolves against
+// the project's base directory.
+type SonarQubeReportBuilder struct {
+	outputDir string
+	logger    *slog.Logger
+}
+
+func NewSonarQubeReportBuilder(outputDir string, logger *slog.Logger) reporter.ReportBuilder {
+	return &SonarQubeReportBuilder{
+		outputDir: outputDir,
+		logger:    logger,
+	}
+}
+
+func (b *SonarQubeReportBuilder) ReportType() string {
+	return "SonarQube"
+}
+
+func (b *SonarQubeReportBuilder) CreateReport(tree *model.SummaryTree) error {
+	files := tree.FilesByPath()
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	report := coverageXML{Version: 1, Files: []fileXML{}}
+	var outside []string
+	for _, path := range paths {
+		if isOutsideProject(path) {
+			outside = append(outside, path)
+			continue
+		}
+		// Sonar rejects files without lines, and they carry no coverage.
+		if element := buildFile(files[path]); len(element.Lines) > 0 {
+			report.Files = append(report.Files, element)
+		}
+	}
+
+	if len(outside) > 0 {
+		b.logger.Warn("Skipped files outside the project root in the SonarQube report", "files", outside)
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal SonarQube report: %w", err)
+	}
+	content := append([]byte(xml.Header), data...)
+	content = append(content, '\n')
+
+	outputPath := filepath.Join(b.outputDir, "SonarQube.xml")
+	if err := os.WriteFile(outputPath, content, 0644); err != nil {
+		return fmt.Errorf("failed to write SonarQube report '%s': %w", outputPath, err)
+	}
+	b.logger.Info("Wrote SonarQube report", "path", outputPath, "files", len(report.Files))
+	return nil
+}
+
+// isOutsideProject reports whether a tree path lies outside the project root.
+// Sonar cannot resolve such files against the project's base directory and
+// fails the whole import on them.
+func isOutsideProject(path string) bool {
+	return path == ".." || strings.HasPrefix(path, "../") || strings.HasPrefix(path, "/") || filepath.IsAbs(path)
+}
+
+// buildFile converts the coverable lines of a file, in line order. Merged
+// reports can count a branch as covered more than once, so the count is capped.
+func buildFile(file *model.FileNode) fileXML {
+	lineNumbers := make([]int, 0, len(file.Lines))
+	for lineNumber, line := range file.Lines {
+		if line.Hits >= 0 {
+			lineNumbers = append(lineNumbers, lineNumber)
+		}
+	}
+	sort.Ints(lineNumbers)
+
+	element := fileXML{Path: file.Path}
+	for _, lineNumber := range lineNumbers {
+		line := file.Lines[lineNumber]
+		lineElement := lineXML{LineNumber: lineNumber, Covered: line.Hits > 0}
+		if line.TotalBranches > 0 {
+			total, covered := line.TotalBranches, min(line.CoveredBranches, line.TotalBranches)
+			lineElement.BranchesToCover, lineElement.CoveredBranches = &total, &covered
+		}
+		element.Lines = append(element.Lines, lineElement)
+	}
+	return element
+}
@@ -0,0 +1,53 @@
+package sonarqube_test
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter/sonarqube"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSonarQubeReportBuilder_CreateReport(t *testing.T) {
+	tree := testutil.NewTree(
+		&model.FileNode{
+			Name: "calc.go",
+			Path: "src/calc.go",
+			Lines: map[int]model.LineMetrics{
+				1:  {Hits: -1},
+				3:  {Hits: 2, TotalBranches: 2, CoveredBranches: 1},
+				10: {Hits: 0},
+				4:  {Hits: 1, TotalBranches: 2, CoveredBranches: 3}, // Over-counted by merged reports.
+			},
+		},
+		testutil.NewFile("src/empty.go", -1),
+		testutil.NewFile("main.go", -1, 5),
+		testutil.NewFile("../shared/util.go", 1),
+	)
+
+	tmpDir := t.TempDir()
+	builder := sonarqube.NewSonarQubeReportBuilder(tmpDir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.NoError(t, builder.CreateReport(tree))
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "SonarQube.xml"))
+	require.NoError(t, err)
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<coverage version="1">` + "\n" +
+		`  <file path="main.go">` + "\n" +
+		`    <lineToCover lineNumber="2" covered="true"></lineToCover>` + "\n" +
+		`  </file>` + "\n" +
+		`  <file path="src/calc.go">` + "\n" +
+		`    <lineToCover lineNumber="3" covered="true" branchesToCover="2" coveredBranches="1"></lineToCover>` + "\n" +
+		`    <lineToCover lineNumber="4" covered="true" branchesToCover="2" coveredBranches="2"></lineToCover>` + "\n" +
+		`    <lineToCover lineNumber="10" covered="false"></lineToCover>` + "\n" +
+		`  </file>` + "\n" +
+		`</coverage>` + "\n"
+	assert.Equal(t, expected, string(content))
+}
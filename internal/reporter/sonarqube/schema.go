@@ -0,0 +1,27 @@
+package sonarqube
+
+import "encoding/xml"
+
+// The elements of SonarQube's generic test coverage format
+// (https://docs.sonarsource.com/sonarqube/latest/analyzing-source-code/test-coverage/generic-test-data/).
+
+// <coverage>
+type coverageXML struct {
+	XMLName xml.Name  `xml:"coverage"`
+	Version int       `xml:"version,attr"`
+	Files   []fileXML `xml:"file"`
+}
+
+// <file>
+type fileXML struct {
+	Path  string    `xml:"path,attr"`
+	Lines []lineXML `xml:"lineToCover"`
+}
+
+// <lineToCover>. The branch attributes must be given together or not at all.
+type lineXML struct {
+	LineNumber      int  `xml:"lineNumber,attr"`
+	Covered         bool `xml:"covered,attr"`
+	BranchesToCover *int `xml:"branchesToCover,attr,omitempty"`
+	CoveredBranches *int `xml:"coveredBranches,attr,omitempty"`
+}
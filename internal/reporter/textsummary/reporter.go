@@ -2,7 +2,9 @@ package textsummary
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -10,20 +12,24 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/IgorBayerl/nanovision/internal/history"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/reporter"
+	"github.com/IgorBayerl/nanovision/internal/risk"
 	"github.com/IgorBayerl/nanovision/internal/utils"
 )
 
 type TextReportBuilder struct {
-	outputDir string
-	logger    *slog.Logger
+	outputDir      string
+	riskThresholds risk.Thresholds
+	logger         *slog.Logger
 }
 
-func NewTextReportBuilder(outputDir string, logger *slog.Logger) reporter.ReportBuilder {
+func NewTextReportBuilder(outputDir string, riskThresholds risk.Thresholds, logger *slog.Logger) reporter.ReportBuilder {
 	return &TextReportBuilder{
-		outputDir: outputDir,
-		logger:    logger,
+		outputDir:      outputDir,
+		riskThresholds: riskThresholds,
+		logger:         logger,
 	}
 }
 
@@ -53,14 +59,31 @@ func (b *TextReportBuilder) CreateReport(tree *model.SummaryTree) error {
 	}
 
 	lineCoverage := utils.CalculatePercentage(tree.Metrics.LinesCovered, tree.Metrics.LinesValid, 1)
-	fmt.Fprintf(f, "  Line coverage: %s\n", utils.FormatPercentage(lineCoverage, 0))
+	fmt.Fprintf(f, "  Line coverage: %s (%s)\n", utils.FormatPercentage(lineCoverage, 0), riskLabel(b.riskThresholds.LineCoverage, lineCoverage))
 	fmt.Fprintf(f, "  Covered lines: %d\n", tree.Metrics.LinesCovered)
 	fmt.Fprintf(f, "  Uncovered lines: %d\n", tree.Metrics.LinesValid-tree.Metrics.LinesCovered)
 	fmt.Fprintf(f, "  Coverable lines: %d\n", tree.Metrics.LinesValid)
 
 	if tree.Metrics.BranchesValid > 0 {
 		branchCoverage := utils.CalculatePercentage(tree.Metrics.BranchesCovered, tree.Metrics.BranchesValid, 1)
-		fmt.Fprintf(f, "  Branch coverage: %s (%d of %d)\n", utils.FormatPercentage(branchCoverage, 0), tree.Metrics.BranchesCovered, tree.Metrics.BranchesValid)
+		fmt.Fprintf(f, "  Branch coverage: %s (%d of %d) (%s)\n", utils.FormatPercentage(branchCoverage, 0), tree.Metrics.BranchesCovered, tree.Metrics.BranchesValid, b.riskThresholds.BranchCoverage.Coverage(branchCoverage))
+	}
+
+	if tree.Metrics.MethodsValid > 0 {
+		methodCoverage := utils.CalculatePercentage(tree.Metrics.MethodsCovered, tree.Metrics.MethodsValid, 1)
+		fmt.Fprintf(f, "  Method coverage: %s (%d of %d) (%s)\n", utils.FormatPercentage(methodCoverage, 0), tree.Metrics.MethodsCovered, tree.Metrics.MethodsValid, b.riskThresholds.MethodCoverage.Coverage(methodCoverage))
+	}
+
+	if tree.Patch != nil {
+		printPatchCoverage(f, tree.Patch)
+	}
+
+	printGateResults(f, tree.GateResults)
+
+	var previous *model.HistorySnapshot
+	if len(tree.History) > 0 {
+		previous = &tree.History[len(tree.History)-1]
+		printHistory(f, tree)
 	}
 
 	// Print the hierarchical summary table.
@@ -69,13 +92,113 @@ func (b *TextReportBuilder) CreateReport(tree *model.SummaryTree) error {
 
 	fmt.Fprintln(tw) // Newline before the table
 	// Start the recursive walk from the root's children.
-	printNode(tw, tree.Root, 0)
+	printNode(tw, tree.Root, 0, b.riskThresholds.LineCoverage, previous)
 
 	return nil
 }
 
+// printPatchCoverage prints the coverage of the lines changed by a diff.
+func printPatchCoverage(w io.Writer, patch *model.PatchCoverage) {
+	patchCoverage := utils.CalculatePercentage(patch.Metrics.LinesCovered, patch.Metrics.LinesValid, 1)
+	fmt.Fprintf(w, "  Patch coverage: %s (%d of %d changed coverable lines, %s)\n", utils.FormatPercentage(patchCoverage, 0), patch.Metrics.LinesCovered, patch.Metrics.LinesValid, patch.Base)
+	if patch.Metrics.BranchesValid > 0 {
+		patchBranchCoverage := utils.CalculatePercentage(patch.Metrics.BranchesCovered, patch.Metrics.BranchesValid, 1)
+		fmt.Fprintf(w, "  Patch branch coverage: %s (%d of %d)\n", utils.FormatPercentage(patchBranchCoverage, 0), patch.Metrics.BranchesCovered, patch.Metrics.BranchesValid)
+	}
+}
+
+// maxTrendPoints caps the runs drawn in the coverage trend.
+const maxTrendPoints = 20
+
+// printHistory prints the change of each coverage metric since the previous
+// run and the trend of the line coverage over the recent runs.
+func printHistory(w io.Writer, tree *model.SummaryTree) {
+	previous := tree.History[len(tree.History)-1]
+	fmt.Fprintf(w, "\nHistory\n")
+	fmt.Fprintf(w, "  Previous runs: %d\n", len(tree.History))
+	fmt.Fprintf(w, "  Compared with: %s\n", describeSnapshot(previous))
+
+	metrics := []struct {
+		name       string
+		percentage history.Percentage
+	}{
+		{"Line coverage", history.LineCoverage},
+		{"Branch coverage", history.BranchCoverage},
+		{"Method coverage", history.MethodCoverage},
+	}
+	for _, metric := range metrics {
+		current := metric.percentage(tree.Metrics)
+		if math.IsNaN(current) {
+			continue
+		}
+		fmt.Fprintf(w, "  %s: %s (%s)\n", metric.name, utils.FormatPercentage(current, 1), formatDelta(current, metric.percentage(previous.Metrics)))
+	}
+
+	trend := history.Trend(tree.History, tree.Metrics, history.LineCoverage, maxTrendPoints)
+	fmt.Fprintf(w, "  Line coverage trend: %s (last %d runs)\n", history.Sparkline(trend), len(trend))
+}
+
+// describeSnapshot names a snapshot by its tag, time and commit.
+func describeSnapshot(snapshot model.HistorySnapshot) string {
+	parts := []string{time.Unix(snapshot.Timestamp, 0).Format(history.TimeLayout)}
+	if snapshot.Tag != "" {
+		parts = append([]string{snapshot.Tag}, parts...)
+	}
+	if snapshot.Commit != "" {
+		parts = append(parts, "commit "+snapshot.Commit)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatDelta formats the change between two percentages. It is "new" when
+// the earlier value was not measured.
+func formatDelta(current, previous float64) string {
+	switch {
+	case math.IsNaN(current):
+		return ""
+	case math.IsNaN(previous):
+		return "new"
+	}
+	return utils.FormatDelta(current-previous, 1)
+}
+
+// printGateResults prints the outcome of the project's quality gates and the
+// paths that failed their minimums, with the actual and required coverage.
+func printGateResults(w io.Writer, results []model.GateResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	var pathResults, failedPaths []model.GateResult
+	fmt.Fprintf(w, "\nQuality gates\n")
+	for _, result := range results {
+		if result.Path == "" {
+			status := "passed"
+			if !result.Passed {
+				status = "FAILED"
+			}
+			fmt.Fprintf(w, "  [%s] %s\n", status, result)
+			continue
+		}
+		pathResults = append(pathResults, result)
+		if !result.Passed {
+			failedPaths = append(failedPaths, result)
+		}
+	}
+
+	if len(pathResults) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "  Path thresholds: %d checked, %d failed\n", len(pathResults), len(failedPaths))
+	for _, result := range failedPaths {
+		fmt.Fprintf(w, "    [FAILED] %s\n", result)
+	}
+}
+
 // printNode is a recursive helper to print the tree hierarchy.
-func printNode(tw *tabwriter.Writer, dir *model.DirNode, indentLevel int) {
+// Each row shows the line coverage and its risk level, followed by its
+// change since the previous run when there is one.
+func printNode(tw *tabwriter.Writer, dir *model.DirNode, indentLevel int, lineBand risk.Band, previous *model.HistorySnapshot) {
 	indent := strings.Repeat("  ", indentLevel)
 
 	// Sort subdirectories by name for consistent output.
@@ -99,13 +222,40 @@ func printNode(tw *tabwriter.Writer, dir *model.DirNode, indentLevel int) {
 	// Print subdirectories first.
 	for _, sub := range sortedSubdirs {
 		lineCov := utils.CalculatePercentage(sub.Metrics.LinesCovered, sub.Metrics.LinesValid, 1)
-		fmt.Fprintf(tw, "%s%s/\t  %s\n", indent, sub.Name, utils.FormatPercentage(lineCov, 0))
-		printNode(tw, sub, indentLevel+1)
+		fmt.Fprintf(tw, "%s%s/\t  %s\t  %s%s\n", indent, sub.Name, utils.FormatPercentage(lineCov, 0), riskLabel(lineBand, lineCov), historyColumn(lineCov, previous, sub.Path, true))
+		printNode(tw, sub, indentLevel+1, lineBand, previous)
 	}
 
 	// Then print files in the current directory.
 	for _, file := range sortedFiles {
 		lineCov := utils.CalculatePercentage(file.Metrics.LinesCovered, file.Metrics.LinesValid, 1)
-		fmt.Fprintf(tw, "%s%s\t  %s\n", indent, file.Name, utils.FormatPercentage(lineCov, 0))
+		fmt.Fprintf(tw, "%s%s\t  %s\t  %s%s\n", indent, file.Name, utils.FormatPercentage(lineCov, 0), riskLabel(lineBand, lineCov), historyColumn(lineCov, previous, file.Path, false))
+	}
+}
+
+// riskLabel names the risk band of a coverage percentage. A directory or file
+// without coverable lines has nothing to classify, so it is shown as
+// unmeasured rather than as a danger.
+func riskLabel(band risk.Band, percentage float64) string {
+	if math.IsNaN(percentage) {
+		return "unmeasured"
+	}
+	return string(band.Coverage(percentage))
+}
+
+// historyColumn returns the change of a row's line coverage since the
+// previous run as an extra column, or nothing without a previous run.
+func historyColumn(lineCov float64, previous *model.HistorySnapshot, path string, isDir bool) string {
+	if previous == nil {
+		return ""
+	}
+	metrics, ok := previous.Files[path]
+	if isDir {
+		metrics, ok = previous.Directories[path]
+	}
+	before := math.NaN()
+	if ok {
+		before = utils.CalculatePercentage(metrics.LinesCovered, metrics.LinesValid, 1)
 	}
+	return "\t  " + formatDelta(lineCov, before)
 }
@@ -0,0 +1,159 @@
+package treediff
+
+import (
+	"html/template"
+	"io"
+	"math"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// The HTML view is a single page without scripts or external resources, so
+// it can be opened from a CI artifact or attached to a review as is.
+var htmlTemplate = template.Must(template.New("diff").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Coverage Diff</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+  h1 { font-size: 1.6rem; }
+  h2 { font-size: 1.2rem; margin-top: 2rem; }
+  table { border-collapse: collapse; margin-top: 0.5rem; }
+  th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; text-align: left; font-variant-numeric: tabular-nums; }
+  th { background: #f6f8fa; }
+  td.path { font-family: ui-monospace, Menlo, Consolas, monospace; }
+  .up { color: #1a7f37; }
+  .down { color: #cf222e; }
+  .status-added { color: #1a7f37; }
+  .status-removed { color: #cf222e; }
+  .lines { font-family: ui-monospace, Menlo, Consolas, monospace; }
+  p.counts { color: #59636e; }
+</style>
+</head>
+<body>
+<h1>Coverage Diff</h1>
+<table>
+  <tr><th>Metric</th><th>Base</th><th>Head</th><th>Change</th></tr>
+  {{- range .Summary}}
+  <tr><td>{{.Name}}</td><td>{{.Base}}</td><td>{{.Head}}</td><td class="{{.Delta.Class}}">{{.Delta.Text}}</td></tr>
+  {{- end}}
+</table>
+<p class="counts">Directories: {{.DirCounts}}<br>Files: {{.FileCounts}}</p>
+{{- if .Directories}}
+<h2>Directories</h2>
+<table>
+  <tr><th>Path</th><th>Status</th>{{range $.MetricNames}}<th>{{.}}</th>{{end}}</tr>
+  {{- range .Directories}}
+  <tr><td class="path">{{.Path}}</td><td class="status-{{.Status}}">{{.Status}}</td>{{range .Cells}}<td class="{{.Class}}">{{.Text}}</td>{{end}}</tr>
+  {{- end}}
+</table>
+{{- end}}
+{{- if .Files}}
+<h2>Files</h2>
+<table>
+  <tr><th>Path</th><th>Status</th>{{range $.MetricNames}}<th>{{.}}</th>{{end}}<th>Newly covered lines</th><th>Newly uncovered lines</th></tr>
+  {{- range .Files}}
+  <tr><td class="path">{{.Path}}</td><td class="status-{{.Status}}">{{.Status}}</td>{{range .Cells}}<td class="{{.Class}}">{{.Text}}</td>{{end}}<td class="lines up">{{.NewlyCovered}}</td><td class="lines down">{{.NewlyUncovered}}</td></tr>
+  {{- end}}
+</table>
+<p class="counts">{{.LineNote}}</p>
+{{- end}}
+</body>
+</html>
+`))
+
+type htmlCell struct {
+	Text  string
+	Class string
+}
+
+type htmlSummaryRow struct {
+	Name  string
+	Base  string
+	Head  string
+	Delta htmlCell
+}
+
+type htmlRow struct {
+	Path           string
+	Status         model.DiffStatus
+	Cells          []htmlCell
+	NewlyCovered   string
+	NewlyUncovered string
+}
+
+type htmlView struct {
+	Summary     []htmlSummaryRow
+	DirCounts   statusCounts
+	FileCounts  statusCounts
+	MetricNames []string
+	Directories []htmlRow
+	Files       []htmlRow
+	LineNote    string
+}
+
+// writeHTML writes the diff as a standalone HTML page.
+func writeHTML(w io.Writer, result *model.TreeDiff) error {
+	view := htmlView{
+		DirCounts:  countDirectories(result),
+		FileCounts: countFiles(result),
+		LineNote:   lineComparisonNote,
+	}
+	for _, m := range metrics {
+		view.MetricNames = append(view.MetricNames, m.name+" coverage")
+		base, head := m.percentage(result.Project.Base), m.percentage(result.Project.Head)
+		if math.IsNaN(base) && math.IsNaN(head) {
+			continue
+		}
+		view.Summary = append(view.Summary, htmlSummaryRow{
+			Name:  m.name + " coverage",
+			Base:  utils.FormatPercentage(base, 2),
+			Head:  utils.FormatPercentage(head, 2),
+			Delta: deltaCell(m.delta(result.Project), 2),
+		})
+	}
+
+	for _, dir := range result.Directories {
+		if dir.Status != model.DiffUnchanged {
+			view.Directories = append(view.Directories, htmlRowFor(dir))
+		}
+	}
+	for _, file := range result.Files {
+		if file.Status != model.DiffUnchanged {
+			row := htmlRowFor(file.NodeDiff)
+			row.NewlyCovered = formatLineRanges(file.NewlyCovered)
+			row.NewlyUncovered = formatLineRanges(file.NewlyUncovered)
+			view.Files = append(view.Files, row)
+		}
+	}
+	return htmlTemplate.Execute(w, view)
+}
+
+func htmlRowFor(node model.NodeDiff) htmlRow {
+	row := htmlRow{Path: node.Path, Status: node.Status}
+	for _, m := range metrics {
+		row.Cells = append(row.Cells, htmlCell{Text: m.cell(node, 1), Class: deltaClass(m.delta(node))})
+	}
+	return row
+}
+
+func deltaCell(delta float64, decimalPlaces int) htmlCell {
+	if math.IsNaN(delta) {
+		return htmlCell{Text: "-"}
+	}
+	return htmlCell{Text: utils.FormatDelta(delta, decimalPlaces), Class: deltaClass(delta)}
+}
+
+// deltaClass colors improvements green and regressions red.
+func deltaClass(delta float64) string {
+	switch {
+	case delta >= 0.05:
+		return "up"
+	case delta <= -0.05:
+		return "down"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,130 @@
+package treediff
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+)
+
+// The JSON schema of the diff. Percentages that were not measured are
+// omitted, as JSON has no NaN.
+
+type metricsJSON struct {
+	LinesCovered    int      `json:"linesCovered"`
+	LinesValid      int      `json:"linesValid"`
+	BranchesCovered int      `json:"branchesCovered"`
+	BranchesValid   int      `json:"branchesValid"`
+	MethodsCovered  int      `json:"methodsCovered"`
+	MethodsValid    int      `json:"methodsValid"`
+	LineCoverage    *float64 `json:"lineCoverage,omitempty"`
+	BranchCoverage  *float64 `json:"branchCoverage,omitempty"`
+	MethodCoverage  *float64 `json:"methodCoverage,omitempty"`
+}
+
+type deltaJSON struct {
+	LinesCovered    int      `json:"linesCovered"`
+	BranchesCovered int      `json:"branchesCovered"`
+	MethodsCovered  int      `json:"methodsCovered"`
+	LineCoverage    *float64 `json:"lineCoverage,omitempty"`
+	BranchCoverage  *float64 `json:"branchCoverage,omitempty"`
+	MethodCoverage  *float64 `json:"methodCoverage,omitempty"`
+}
+
+type nodeJSON struct {
+	Path   string           `json:"path,omitempty"`
+	Status model.DiffStatus `json:"status"`
+	Base   *metricsJSON     `json:"base,omitempty"`
+	Head   *metricsJSON     `json:"head,omitempty"`
+	Delta  *deltaJSON       `json:"delta,omitempty"`
+}
+
+type fileJSON struct {
+	nodeJSON
+	NewlyCovered   []int `json:"newlyCovered,omitempty"`
+	NewlyUncovered []int `json:"newlyUncovered,omitempty"`
+}
+
+type diffJSON struct {
+	Project     nodeJSON     `json:"project"`
+	Directories []nodeJSON   `json:"directories"`
+	Files       []fileJSON   `json:"files"`
+	DirCounts   statusCounts `json:"directoryCounts"`
+	FileCounts  statusCounts `json:"fileCounts"`
+}
+
+// writeJSON writes the diff as JSON. Like the other outputs, it lists only
+// the directories and files that changed; the counts include the others.
+func writeJSON(w io.Writer, result *model.TreeDiff) error {
+	output := diffJSON{
+		Project:     toNodeJSON(result.Project),
+		Directories: []nodeJSON{},
+		Files:       []fileJSON{},
+		DirCounts:   countDirectories(result),
+		FileCounts:  countFiles(result),
+	}
+	for _, dir := range result.Directories {
+		if dir.Status != model.DiffUnchanged {
+			output.Directories = append(output.Directories, toNodeJSON(dir))
+		}
+	}
+	for _, file := range result.Files {
+		if file.Status != model.DiffUnchanged {
+			output.Files = append(output.Files, fileJSON{
+				nodeJSON:       toNodeJSON(file.NodeDiff),
+				NewlyCovered:   file.NewlyCovered,
+				NewlyUncovered: file.NewlyUncovered,
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func toNodeJSON(node model.NodeDiff) nodeJSON {
+	output := nodeJSON{Path: node.Path, Status: node.Status}
+	if node.Status != model.DiffAdded {
+		output.Base = toMetricsJSON(node.Base)
+	}
+	if node.Status != model.DiffRemoved {
+		output.Head = toMetricsJSON(node.Head)
+	}
+	if output.Base != nil && output.Head != nil {
+		output.Delta = &deltaJSON{
+			LinesCovered:    node.Head.LinesCovered - node.Base.LinesCovered,
+			BranchesCovered: node.Head.BranchesCovered - node.Base.BranchesCovered,
+			MethodsCovered:  node.Head.MethodsCovered - node.Base.MethodsCovered,
+			LineCoverage:    optional(lineMetric.delta(node)),
+			BranchCoverage:  optional(branchMetric.delta(node)),
+			MethodCoverage:  optional(methodMetric.delta(node)),
+		}
+	}
+	return output
+}
+
+func toMetricsJSON(m model.CoverageMetrics) *metricsJSON {
+	return &metricsJSON{
+		LinesCovered:    m.LinesCovered,
+		LinesValid:      m.LinesValid,
+		BranchesCovered: m.BranchesCovered,
+		BranchesValid:   m.BranchesValid,
+		MethodsCovered:  m.MethodsCovered,
+		MethodsValid:    m.MethodsValid,
+		LineCoverage:    optional(lineMetric.percentage(m)),
+		BranchCoverage:  optional(branchMetric.percentage(m)),
+		MethodCoverage:  optional(methodMetric.percentage(m)),
+	}
+}
+
+// optional returns nil for a value that was not measured. Deltas are
+// rounded to two decimals, as the percentages they are computed from.
+func optional(value float64) *float64 {
+	if math.IsNaN(value) {
+		return nil
+	}
+	value = math.Round(value*100) / 100
+	return &value
+}
@@ -0,0 +1,178 @@
+package treediff
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/diff"
+	"github.com/IgorBayerl/nanovision/internal/history"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/reporter"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// DiffReportBuilder compares a tree with its baseline and writes the
+// difference as text, JSON and a standalone HTML page.
+type DiffReportBuilder struct {
+	outputDir string
+	logger    *slog.Logger
+}
+
+func NewDiffReportBuilder(outputDir string, logger *slog.Logger) reporter.ReportBuilder {
+	return &DiffReportBuilder{
+		outputDir: outputDir,
+		logger:    logger,
+	}
+}
+
+func (b *DiffReportBuilder) ReportType() string {
+	return "Diff"
+}
+
+// CreateReport compares tree.Baseline, the base, with tree, the head.
+func (b *DiffReportBuilder) CreateReport(tree *model.SummaryTree) error {
+	if tree.Baseline == nil {
+		return errors.New("the Diff report needs a baseline to compare with, set 'baseline' to the RawJson report of the other run")
+	}
+
+	result := diff.Compare(tree.Baseline, tree)
+	b.logger.Info("Writing coverage diff", "directory", b.outputDir, "files", len(result.Files))
+
+	writers := []struct {
+		fileName string
+		write    func(io.Writer, *model.TreeDiff) error
+	}{
+		{"Diff.txt", writeText},
+		{"Diff.json", writeJSON},
+		{"Diff.html", writeHTML},
+	}
+	for _, w := range writers {
+		outputPath := filepath.Join(b.outputDir, w.fileName)
+		if err := writeFile(outputPath, result, w.write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(outputPath string, result *model.TreeDiff, write func(io.Writer, *model.TreeDiff) error) error {
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer f.Close()
+
+	if err := write(f, result); err != nil {
+		return fmt.Errorf("failed to write coverage diff to '%s': %w", outputPath, err)
+	}
+	return nil
+}
+
+// metric is a coverage percentage the diff reports compare.
+type metric struct {
+	name       string
+	percentage history.Percentage
+}
+
+var (
+	lineMetric   = metric{"Line", history.LineCoverage}
+	branchMetric = metric{"Branch", history.BranchCoverage}
+	methodMetric = metric{"Method", history.MethodCoverage}
+
+	metrics = []metric{lineMetric, branchMetric, methodMetric}
+)
+
+// delta returns the change of a metric, or NaN when it was not measured in
+// both runs.
+func (m metric) delta(node model.NodeDiff) float64 {
+	if node.Status == model.DiffAdded || node.Status == model.DiffRemoved {
+		return math.NaN()
+	}
+	return m.percentage(node.Head) - m.percentage(node.Base)
+}
+
+// cell describes a metric of a node for a table: its head value and change,
+// or the base value of a removed node.
+func (m metric) cell(node model.NodeDiff, decimalPlaces int) string {
+	if node.Status == model.DiffRemoved {
+		base := m.percentage(node.Base)
+		if math.IsNaN(base) {
+			return "-"
+		}
+		return "was " + utils.FormatPercentage(base, decimalPlaces)
+	}
+
+	head := m.percentage(node.Head)
+	text := utils.FormatPercentage(head, decimalPlaces)
+	if delta := m.delta(node); !math.IsNaN(delta) {
+		text += " (" + utils.FormatDelta(delta, decimalPlaces) + ")"
+	}
+	return text
+}
+
+// statusCounts counts the nodes of each status.
+type statusCounts struct {
+	Added     int `json:"added"`
+	Removed   int `json:"removed"`
+	Changed   int `json:"changed"`
+	Unchanged int `json:"unchanged"`
+}
+
+func (c *statusCounts) add(status model.DiffStatus) {
+	switch status {
+	case model.DiffAdded:
+		c.Added++
+	case model.DiffRemoved:
+		c.Removed++
+	case model.DiffChanged:
+		c.Changed++
+	default:
+		c.Unchanged++
+	}
+}
+
+func (c statusCounts) String() string {
+	return fmt.Sprintf("%d added, %d removed, %d changed, %d unchanged", c.Added, c.Removed, c.Changed, c.Unchanged)
+}
+
+func countDirectories(result *model.TreeDiff) statusCounts {
+	var counts statusCounts
+	for _, dir := range result.Directories {
+		counts.add(dir.Status)
+	}
+	return counts
+}
+
+func countFiles(result *model.TreeDiff) statusCounts {
+	var counts statusCounts
+	for _, file := range result.Files {
+		counts.add(file.Status)
+	}
+	return counts
+}
+
+// formatLineRanges formats ascending line numbers, joining consecutive lines
+// into ranges: "3-5, 9".
+func formatLineRanges(lines []int) string {
+	var parts []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.Itoa(lines[i]))
+		} else {
+			parts = append(parts, strconv.Itoa(lines[i])+"-"+strconv.Itoa(lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,82 @@
+package treediff_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/reporter/treediff"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffReportBuilder_CreateReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	builder := treediff.NewDiffReportBuilder(tmpDir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	head := testutil.NewTree(testutil.NewFile("a.go", 1, 0, 0, 0), testutil.NewFile("new.go", 1), testutil.NewFile("same.go", 1))
+	head.Baseline = testutil.NewTree(testutil.NewFile("a.go", 1, 1, 1, 0), testutil.NewFile("old.go", 0, 1), testutil.NewFile("same.go", 1))
+
+	require.NoError(t, builder.CreateReport(head))
+
+	text, err := os.ReadFile(filepath.Join(tmpDir, "Diff.txt"))
+	require.NoError(t, err)
+	expected := "Coverage diff\n" +
+		"  Line coverage: 71.42% -> 50.00% (-21.42%)\n" +
+		"  Directories: 0 added, 0 removed, 0 changed, 0 unchanged\n" +
+		"  Files: 1 added, 1 removed, 1 changed, 1 unchanged\n" +
+		"\n" +
+		"Files\n" +
+		"  Path    Status   Line            Branch  Method  Newly covered  Newly uncovered\n" +
+		"  a.go    changed  25.0% (-50.0%)  N/A     N/A     0              2\n" +
+		"  new.go  added    100.0%          N/A     N/A     0              0\n" +
+		"  old.go  removed  was 50.0%       -       -       0              0\n" +
+		"\n" +
+		"Newly uncovered lines\n" +
+		"  a.go: 2-3\n" +
+		"\n" +
+		"Lines are compared by number and only where they are coverable in both runs; edits that move code can shift them.\n"
+	assert.Equal(t, expected, string(text))
+
+	jsonData, err := os.ReadFile(filepath.Join(tmpDir, "Diff.json"))
+	require.NoError(t, err)
+	var parsed struct {
+		Project struct {
+			Delta struct {
+				LinesCovered int      `json:"linesCovered"`
+				LineCoverage *float64 `json:"lineCoverage"`
+			} `json:"delta"`
+		} `json:"project"`
+		Files []struct {
+			Path           string `json:"path"`
+			Status         string `json:"status"`
+			NewlyUncovered []int  `json:"newlyUncovered"`
+		} `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal(jsonData, &parsed))
+	assert.Equal(t, -2, parsed.Project.Delta.LinesCovered)
+	require.NotNil(t, parsed.Project.Delta.LineCoverage)
+	assert.InDelta(t, -21.42, *parsed.Project.Delta.LineCoverage, 0.001)
+	require.Len(t, parsed.Files, 3, "Unchanged files should not be listed")
+	assert.Equal(t, "a.go", parsed.Files[0].Path)
+	assert.Equal(t, []int{2, 3}, parsed.Files[0].NewlyUncovered)
+	assert.Equal(t, "removed", parsed.Files[2].Status)
+
+	html, err := os.ReadFile(filepath.Join(tmpDir, "Diff.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(html), `<td class="path">old.go</td><td class="status-removed">removed</td>`)
+	assert.Contains(t, string(html), `<td class="lines down">2-3</td>`)
+	assert.Contains(t, string(html), "Lines are compared by number")
+}
+
+func TestDiffReportBuilder_RequiresBaseline(t *testing.T) {
+	builder := treediff.NewDiffReportBuilder(t.TempDir(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := builder.CreateReport(testutil.NewTree(testutil.NewFile("a.go", 1)))
+
+	assert.ErrorContains(t, err, "baseline")
+}
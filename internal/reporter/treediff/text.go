@@ -0,0 +1,100 @@
+package treediff
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+)
+
+// lineComparisonNote states the limit of the line lists of the text and HTML
+// views: lines are matched by number, not mapped through the edits.
+const lineComparisonNote = "Lines are compared by number and only where they are coverable in both runs; edits that move code can shift them."
+
+// writeText writes the diff as plain text: the project totals, then the
+// directories and files that changed, then their changed lines.
+func writeText(w io.Writer, result *model.TreeDiff) error {
+	fmt.Fprintf(w, "Coverage diff\n")
+	for _, m := range metrics {
+		base, head := m.percentage(result.Project.Base), m.percentage(result.Project.Head)
+		if math.IsNaN(base) && math.IsNaN(head) {
+			continue
+		}
+		line := fmt.Sprintf("  %s coverage: %s -> %s", m.name, utils.FormatPercentage(base, 2), utils.FormatPercentage(head, 2))
+		if delta := m.delta(result.Project); !math.IsNaN(delta) {
+			line += " (" + utils.FormatDelta(delta, 2) + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "  Directories: %s\n", countDirectories(result))
+	fmt.Fprintf(w, "  Files: %s\n", countFiles(result))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := "  Path\tStatus"
+	for _, m := range metrics {
+		header += "\t" + m.name
+	}
+
+	var changedDirs []model.NodeDiff
+	for _, dir := range result.Directories {
+		if dir.Status != model.DiffUnchanged {
+			changedDirs = append(changedDirs, dir)
+		}
+	}
+	if len(changedDirs) > 0 {
+		fmt.Fprintf(tw, "\nDirectories\n%s\n", header)
+		for _, dir := range changedDirs {
+			fmt.Fprintf(tw, "  %s\t%s%s\n", dir.Path, dir.Status, metricCells(dir))
+		}
+	}
+
+	var changedFiles []model.FileDiff
+	for _, file := range result.Files {
+		if file.Status != model.DiffUnchanged {
+			changedFiles = append(changedFiles, file)
+		}
+	}
+	if len(changedFiles) > 0 {
+		fmt.Fprintf(tw, "\nFiles\n%s\tNewly covered\tNewly uncovered\n", header)
+		for _, file := range changedFiles {
+			fmt.Fprintf(tw, "  %s\t%s%s\t%d\t%d\n", file.Path, file.Status, metricCells(file.NodeDiff), len(file.NewlyCovered), len(file.NewlyUncovered))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	printLines(w, "Newly uncovered lines", changedFiles, func(file model.FileDiff) []int { return file.NewlyUncovered })
+	printLines(w, "Newly covered lines", changedFiles, func(file model.FileDiff) []int { return file.NewlyCovered })
+	if len(changedFiles) > 0 {
+		fmt.Fprintf(w, "\n%s\n", lineComparisonNote)
+	}
+	return nil
+}
+
+func metricCells(node model.NodeDiff) string {
+	var sb strings.Builder
+	for _, m := range metrics {
+		sb.WriteString("\t" + m.cell(node, 1))
+	}
+	return sb.String()
+}
+
+func printLines(w io.Writer, title string, files []model.FileDiff, lines func(model.FileDiff) []int) {
+	printed := false
+	for _, file := range files {
+		fileLines := lines(file)
+		if len(fileLines) == 0 {
+			continue
+		}
+		if !printed {
+			fmt.Fprintf(w, "\n%s\n", title)
+			printed = true
+		}
+		fmt.Fprintf(w, "  %s: %s\n", file.Path, formatLineRanges(fileLines))
+	}
+}
@@ -0,0 +1,103 @@
+// Package risk classifies coverage and complexity values into the safe,
+// warning and danger bands that the reports color and label them with.
+//
+// The bands are configured per metric in nanovision.yaml:
+//
+//	risk_thresholds:
+//	  line_coverage:         { safe: 80, warning: 60 }
+//	  branch_coverage:       { safe: 70, warning: 50 }
+//	  method_coverage:       { safe: 80, warning: 60 }
+//	  cyclomatic_complexity: { safe: 10, warning: 20 }
+//
+// For coverage, higher is better: a value of at least "safe" is safe, and a
+// value of at least "warning" is a warning. For complexity, lower is better:
+// a value of at most "safe" is safe, and a value of at most "warning" is a
+// warning. Anything else is a danger.
+package risk
+
+import (
+	"fmt"
+	"math"
+)
+
+// Level is the risk band a value falls into.
+type Level string
+
+const (
+	Safe    Level = "safe"
+	Warning Level = "warning"
+	Danger  Level = "danger"
+)
+
+// Band holds the limits of the safe and warning bands of a metric.
+type Band struct {
+	Safe    float64 `yaml:"safe" json:"safe"`
+	Warning float64 `yaml:"warning" json:"warning"`
+}
+
+// Thresholds holds the bands of every metric the reports classify.
+type Thresholds struct {
+	LineCoverage         Band `yaml:"line_coverage"`
+	BranchCoverage       Band `yaml:"branch_coverage"` // Also classifies the branch coverage of each method.
+	MethodCoverage       Band `yaml:"method_coverage"` // Classifies both the share of methods covered and of methods fully covered.
+	CyclomaticComplexity Band `yaml:"cyclomatic_complexity"`
+}
+
+// DefaultThresholds returns the bands used when none are configured.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		LineCoverage:         Band{Safe: 80, Warning: 60},
+		BranchCoverage:       Band{Safe: 80, Warning: 60},
+		MethodCoverage:       Band{Safe: 80, Warning: 60},
+		CyclomaticComplexity: Band{Safe: 10, Warning: 20},
+	}
+}
+
+// Validate checks that the coverage bands are percentages in a sensible
+// order and that the complexity band is not inverted.
+func (t Thresholds) Validate() error {
+	coverageBands := []struct {
+		name string
+		band Band
+	}{
+		{"line_coverage", t.LineCoverage},
+		{"branch_coverage", t.BranchCoverage},
+		{"method_coverage", t.MethodCoverage},
+	}
+	for _, c := range coverageBands {
+		if c.band.Warning < 0 || c.band.Safe > 100 || c.band.Warning > c.band.Safe {
+			return fmt.Errorf("risk threshold %s: expected 0 <= warning <= safe <= 100, got warning %g and safe %g", c.name, c.band.Warning, c.band.Safe)
+		}
+	}
+	if t.CyclomaticComplexity.Safe < 0 || t.CyclomaticComplexity.Warning < t.CyclomaticComplexity.Safe {
+		return fmt.Errorf("risk threshold cyclomatic_complexity: expected 0 <= safe <= warning, got safe %g and warning %g", t.CyclomaticComplexity.Safe, t.CyclomaticComplexity.Warning)
+	}
+	return nil
+}
+
+// Coverage classifies a coverage percentage, where higher is better. A value
+// that was not measured (NaN) is a danger, as nothing is known to be covered.
+func (b Band) Coverage(percentage float64) Level {
+	switch {
+	case math.IsNaN(percentage):
+		return Danger
+	case percentage >= b.Safe:
+		return Safe
+	case percentage >= b.Warning:
+		return Warning
+	default:
+		return Danger
+	}
+}
+
+// Complexity classifies a complexity value, where lower is better.
+func (b Band) Complexity(value float64) Level {
+	switch {
+	case value <= b.Safe:
+		return Safe
+	case value <= b.Warning:
+		return Warning
+	default:
+		return Danger
+	}
+}
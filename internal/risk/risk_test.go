@@ -0,0 +1,69 @@
+package risk_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/risk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBand_Coverage(t *testing.T) {
+	band := risk.Band{Safe: 70, Warning: 50}
+
+	testCases := []struct {
+		percentage float64
+		expected   risk.Level
+	}{
+		{100, risk.Safe},
+		{70, risk.Safe},
+		{69.99, risk.Warning},
+		{50, risk.Warning},
+		{49.99, risk.Danger},
+		{0, risk.Danger},
+		{math.NaN(), risk.Danger},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, band.Coverage(tc.percentage), "percentage %g", tc.percentage)
+	}
+}
+
+func TestBand_Complexity(t *testing.T) {
+	band := risk.DefaultThresholds().CyclomaticComplexity
+
+	assert.Equal(t, risk.Safe, band.Complexity(1))
+	assert.Equal(t, risk.Safe, band.Complexity(10))
+	assert.Equal(t, risk.Warning, band.Complexity(11))
+	assert.Equal(t, risk.Warning, band.Complexity(20))
+	assert.Equal(t, risk.Danger, band.Complexity(21))
+}
+
+func TestThresholds_Validate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		modify      func(*risk.Thresholds)
+		expectError bool
+	}{
+		{name: "Defaults are valid", modify: func(*risk.Thresholds) {}},
+		{name: "Team policy for branches", modify: func(th *risk.Thresholds) { th.BranchCoverage = risk.Band{Safe: 70, Warning: 50} }},
+		{name: "Warning above safe", modify: func(th *risk.Thresholds) { th.LineCoverage = risk.Band{Safe: 50, Warning: 70} }, expectError: true},
+		{name: "Safe above 100", modify: func(th *risk.Thresholds) { th.MethodCoverage = risk.Band{Safe: 120, Warning: 60} }, expectError: true},
+		{name: "Inverted complexity", modify: func(th *risk.Thresholds) { th.CyclomaticComplexity = risk.Band{Safe: 20, Warning: 10} }, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			thresholds := risk.DefaultThresholds()
+			tc.modify(&thresholds)
+
+			err := thresholds.Validate()
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/IgorBayerl/nanovision/filtering"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/pathmapping"
 )
 
 // MockParserConfig implements the lean parsers.ParserConfig interface for testing.
@@ -13,11 +14,13 @@ type MockParserConfig struct {
 	SrcDirs    []string
 	FileFilter filtering.IFilter
 	Log        *slog.Logger
+	Mapper     *pathmapping.Mapper
 }
 
-func (m *MockParserConfig) SourceDirectories() []string    { return m.SrcDirs }
-func (m *MockParserConfig) FileFilters() filtering.IFilter { return m.FileFilter }
-func (m *MockParserConfig) Logger() *slog.Logger           { return m.Log }
+func (m *MockParserConfig) SourceDirectories() []string     { return m.SrcDirs }
+func (m *MockParserConfig) FileFilters() filtering.IFilter  { return m.FileFilter }
+func (m *MockParserConfig) Logger() *slog.Logger            { return m.Log }
+func (m *MockParserConfig) PathMapper() *pathmapping.Mapper { return m.Mapper }
 
 // NewTestConfig creates a default, permissive config suitable for most parser tests.
 func NewTestConfig(sourceDirs []string) parsers.ParserConfig {
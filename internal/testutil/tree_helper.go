@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	"path"
+	"strings"
+
+	"github.com/IgorBayerl/nanovision/internal/model"
+)
+
+// NewTree builds a summary tree holding the given files. Each file is placed
+// in the directory of its path, which is created as needed, and its metrics
+// are added to every directory above it and to the tree.
+func NewTree(files ...*model.FileNode) *model.SummaryTree {
+	root := newDir("Root", ".", nil)
+	tree := &model.SummaryTree{Root: root}
+	for _, file := range files {
+		dir := root
+		if parent := path.Dir(file.Path); parent != "." {
+			for _, name := range strings.Split(parent, "/") {
+				subdir, ok := dir.Subdirs[name]
+				if !ok {
+					subdir = newDir(name, strings.TrimPrefix(dir.Path+"/"+name, "./"), dir)
+					dir.Subdirs[name] = subdir
+				}
+				dir = subdir
+			}
+		}
+		file.Parent = dir
+		dir.Files[file.Name] = file
+
+		for ; dir != nil; dir = dir.Parent {
+			addMetrics(&dir.Metrics, file.Metrics)
+		}
+		addMetrics(&tree.Metrics, file.Metrics)
+	}
+	return tree
+}
+
+// NewFile builds a file at a project-relative path from the hits of its
+// lines, starting at line 1. A hit of -1 marks a line that is not coverable.
+func NewFile(filePath string, hits ...int) *model.FileNode {
+	file := &model.FileNode{Name: path.Base(filePath), Path: filePath, Lines: make(map[int]model.LineMetrics)}
+	for i, hit := range hits {
+		file.Lines[i+1] = model.LineMetrics{Hits: hit}
+		if hit < 0 {
+			continue
+		}
+		file.Metrics.LinesValid++
+		if hit > 0 {
+			file.Metrics.LinesCovered++
+		}
+	}
+	return file
+}
+
+func newDir(name, dirPath string, parent *model.DirNode) *model.DirNode {
+	return &model.DirNode{
+		Name:    name,
+		Path:    dirPath,
+		Parent:  parent,
+		Subdirs: make(map[string]*model.DirNode),
+		Files:   make(map[string]*model.FileNode),
+	}
+}
+
+func addMetrics(total *model.CoverageMetrics, m model.CoverageMetrics) {
+	total.LinesCovered += m.LinesCovered
+	total.LinesValid += m.LinesValid
+	total.BranchesCovered += m.BranchesCovered
+	total.BranchesValid += m.BranchesValid
+	total.TotalLines += m.TotalLines
+	total.MethodsCovered += m.MethodsCovered
+	total.MethodsFullyCovered += m.MethodsFullyCovered
+	total.MethodsValid += m.MethodsValid
+}
@@ -1,6 +1,7 @@
 package tree
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"path"
@@ -8,7 +9,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/IgorBayerl/nanovision/filereader"
 	"github.com/IgorBayerl/nanovision/filtering"
 	"github.com/IgorBayerl/nanovision/internal/model"
 	"github.com/IgorBayerl/nanovision/internal/parsers"
@@ -17,14 +17,14 @@ import (
 
 type Builder struct {
 	projectRoot string
-	fileReader  filereader.Reader
+	resolver    *utils.SourceResolver
 	fileFilter  filtering.IFilter
 }
 
-func NewBuilder(projectRoot string, fileFilter filtering.IFilter) *Builder {
+func NewBuilder(projectRoot string, fileFilter filtering.IFilter, resolver *utils.SourceResolver) *Builder {
 	return &Builder{
 		projectRoot: projectRoot,
-		fileReader:  filereader.NewDefaultReader(),
+		resolver:    resolver,
 		fileFilter:  fileFilter,
 	}
 }
@@ -51,9 +51,13 @@ func (b *Builder) BuildTree(results []*parsers.ParserResult) (*model.SummaryTree
 	reportNameMap := make(map[string]int)
 	for _, result := range results {
 		reportKey := result.ReportPattern
-		if _, exists := reportNameMap[reportKey]; !exists {
+		index, exists := reportNameMap[reportKey]
+		if !exists {
 			reportNameMap[reportKey] = len(tree.ReportNames)
 			tree.ReportNames = append(tree.ReportNames, reportKey)
+			tree.ReportModes = append(tree.ReportModes, result.CoverageMode)
+		} else if tree.ReportModes[index] != result.CoverageMode {
+			tree.ReportModes[index] = "" // The files of the report disagree.
 		}
 	}
 	numReports := len(tree.ReportNames)
@@ -72,14 +76,32 @@ func (b *Builder) BuildTree(results []*parsers.ParserResult) (*model.SummaryTree
 	sort.Strings(parserNames) // Sort for consistent output
 	tree.ParserNames = parserNames
 
+	diagnostics := make(map[string]model.FileDiagnostic)
+	addDiagnostic := func(diagnostic model.FileDiagnostic) {
+		key := string(diagnostic.Kind) + "\x00" + diagnostic.Path + "\x00" + diagnostic.Report
+		if _, exists := diagnostics[key]; !exists {
+			diagnostics[key] = diagnostic
+		}
+	}
+
 	for _, result := range results {
+		reportFile := result.ReportFile
+		if reportFile == "" {
+			reportFile = result.ReportPattern
+		}
+
+		seenPaths := make(map[string]struct{}, len(result.FileCoverage))
 		for _, fileCov := range result.FileCoverage {
+			seenPaths[fileCov.Path] = struct{}{}
+
 			// Find the canonical absolute path of the file.
-			absoluteFilePath, err := utils.FindFileInSourceDirs(fileCov.Path, []string{result.SourceDirectory}, b.fileReader, logger)
+			resolution, err := b.resolver.Resolve(fileCov.Path, []string{result.SourceDirectory})
 			if err != nil {
 				logger.Warn("Could not resolve file path, skipping file.", "path", fileCov.Path, "sourceDir", result.SourceDirectory, "error", err)
+				addDiagnostic(unresolvedDiagnostic(fileCov.Path, reportFile, result.SourceDirectory, err))
 				continue
 			}
+			absoluteFilePath := resolution.Path
 
 			// Make it relative to our project root.
 			relativeToProjectRoot, err := filepath.Rel(b.projectRoot, absoluteFilePath)
@@ -94,23 +116,103 @@ func (b *Builder) BuildTree(results []*parsers.ParserResult) (*model.SummaryTree
 			// Apply filtering on project relative path.
 			if !b.fileFilter.IsElementIncludedInReport(finalPath) {
 				logger.Debug("File excluded by filter", "path", finalPath)
+				addDiagnostic(model.FileDiagnostic{
+					Kind:         model.DiagnosticFiltered,
+					Path:         fileCov.Path,
+					Report:       reportFile,
+					ResolvedPath: finalPath,
+					Detail:       "excluded by the file filters",
+				})
 				continue
 			}
 
+			if resolution.Strategy.IsFuzzy() {
+				addDiagnostic(model.FileDiagnostic{
+					Kind:         model.DiagnosticFuzzyMatch,
+					Path:         fileCov.Path,
+					Report:       reportFile,
+					ResolvedPath: finalPath,
+					Detail:       "matched by " + resolution.Strategy.String(),
+				})
+			}
+
 			reportKey := result.ReportPattern
 			reportIndex := reportNameMap[reportKey]
-			fileNode := b.findOrCreateFileNode(tree.Root, finalPath, result.SourceDirectory)
+			fileNode := b.findOrCreateFileNode(tree.Root, finalPath, result.SourceDirectory, absoluteFilePath)
 			b.mergeLineMetrics(fileNode, fileCov.Lines, reportIndex, numReports)
+			b.mergeReportedMethods(fileNode, fileCov.Methods)
+		}
+
+		// Some parsers drop the files they cannot resolve instead of passing
+		// them on, so those are only known from the parser's own list.
+		for _, unresolvedPath := range result.UnresolvedSourceFiles {
+			if _, seen := seenPaths[unresolvedPath]; seen {
+				continue
+			}
+			addDiagnostic(model.FileDiagnostic{
+				Kind:   model.DiagnosticUnresolved,
+				Path:   unresolvedPath,
+				Report: reportFile,
+				Detail: "not found by the " + result.ParserName + " parser",
+			})
 		}
 	}
 
+	tree.Diagnostics = sortedDiagnostics(diagnostics)
+
 	// After all files are added and merged, perform a final aggregation pass.
 	tree.Metrics = b.aggregateMetrics(tree.Root)
 
 	return tree, nil
 }
 
-func (b *Builder) findOrCreateFileNode(startNode *model.DirNode, filePath string, sourceDir string) *model.FileNode {
+// unresolvedDiagnostic describes a report path that could not be resolved,
+// telling apart paths that were not found from those that were ambiguous.
+func unresolvedDiagnostic(reportPath, reportFile, sourceDir string, err error) model.FileDiagnostic {
+	var ambiguous *utils.AmbiguousPathError
+	if errors.As(err, &ambiguous) {
+		return model.FileDiagnostic{
+			Kind:       model.DiagnosticAmbiguous,
+			Path:       reportPath,
+			Report:     reportFile,
+			Candidates: ambiguous.Candidates,
+			Detail:     fmt.Sprintf("matches %d source files", len(ambiguous.Candidates)),
+		}
+	}
+	return model.FileDiagnostic{
+		Kind:   model.DiagnosticUnresolved,
+		Path:   reportPath,
+		Report: reportFile,
+		Detail: "not found in " + sourceDir,
+	}
+}
+
+// sortedDiagnostics orders diagnostics by kind, path and report, so that
+// reports list them in a stable order.
+func sortedDiagnostics(diagnostics map[string]model.FileDiagnostic) []model.FileDiagnostic {
+	kindOrder := make(map[model.DiagnosticKind]int, len(model.DiagnosticKinds))
+	for i, kind := range model.DiagnosticKinds {
+		kindOrder[kind] = i
+	}
+
+	sorted := make([]model.FileDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		sorted = append(sorted, diagnostic)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Kind != b.Kind {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Report < b.Report
+	})
+	return sorted
+}
+
+func (b *Builder) findOrCreateFileNode(startNode *model.DirNode, filePath string, sourceDir string, absolutePath string) *model.FileNode {
 	parts := strings.Split(filePath, "/")
 	currentNode := startNode
 
@@ -131,11 +233,12 @@ func (b *Builder) findOrCreateFileNode(startNode *model.DirNode, filePath string
 	fileName := parts[len(parts)-1]
 	if _, ok := currentNode.Files[fileName]; !ok {
 		newFile := &model.FileNode{
-			Name:      fileName,
-			Path:      filePath,
-			Lines:     make(map[int]model.LineMetrics),
-			Parent:    currentNode,
-			SourceDir: sourceDir,
+			Name:         fileName,
+			Path:         filePath,
+			Lines:        make(map[int]model.LineMetrics),
+			Parent:       currentNode,
+			SourceDir:    sourceDir,
+			AbsolutePath: absolutePath,
 		}
 		currentNode.Files[fileName] = newFile
 	}
@@ -159,10 +262,45 @@ func (b *Builder) mergeLineMetrics(node *model.FileNode, newLines map[int]model.
 		if newLineMetric.TotalBranches > 0 {
 			existing.TotalBranches = newLineMetric.TotalBranches
 		}
+
+		// Reports of the same code describe the same statements, so their
+		// counts are not added up. Which statements were covered is not known,
+		// so the best-covered report is kept.
+		existing.TotalStatements = max(existing.TotalStatements, newLineMetric.TotalStatements)
+		existing.CoveredStatements = max(existing.CoveredStatements, newLineMetric.CoveredStatements)
+		existing.UnexecutedBlock = existing.UnexecutedBlock || newLineMetric.UnexecutedBlock
 		node.Lines[lineNum] = existing
 	}
 }
 
+// mergeReportedMethods adds the report-provided methods of a file to its node.
+// Reports in different formats name and place the same method differently
+// (e.g. LCOV starts it on its signature line, Cobertura on its first
+// statement), so a method that overlaps one already added by an earlier report
+// is taken to be the same method. Methods of a single report may nest
+// (closures, lambdas) and are all kept.
+func (b *Builder) mergeReportedMethods(node *model.FileNode, methods []model.MethodMetrics) {
+	earlierMethods := node.Methods
+	for _, method := range methods {
+		exists := false
+		for _, existing := range earlierMethods {
+			if methodsOverlap(existing, method) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			node.Methods = append(node.Methods, method)
+		}
+	}
+}
+
+// methodsOverlap reports whether the line ranges of two methods share a line.
+// A method without an end line covers only its start line.
+func methodsOverlap(a, b model.MethodMetrics) bool {
+	return a.StartLine <= max(b.EndLine, b.StartLine) && b.StartLine <= max(a.EndLine, a.StartLine)
+}
+
 // aggregateMetrics performs a bottom-up aggregation of metrics throughout the tree.
 func (b *Builder) aggregateMetrics(dir *model.DirNode) model.CoverageMetrics {
 	var dirMetrics model.CoverageMetrics
@@ -0,0 +1,149 @@
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/filtering"
+	"github.com/IgorBayerl/nanovision/internal/model"
+	"github.com/IgorBayerl/nanovision/internal/parsers"
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/tree"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_BuildTree_Diagnostics(t *testing.T) {
+	// Arrange
+	mockFS := testutil.NewMockFilesystem("unix")
+	mockFS.AddFile("/project/src/app.go", "package src")
+	mockFS.AddFile("/project/src/deep/util.go", "package deep")
+	mockFS.AddFile("/project/api/handler.go", "package api")
+	mockFS.AddFile("/project/web/handler.go", "package web")
+	mockFS.AddFile("/project/gen/types.pb.go", "package gen")
+	resolver := utils.NewSourceResolver(mockFS, nil)
+
+	filter, err := filtering.NewDefaultFilter([]string{"-gen/*"})
+	require.NoError(t, err)
+
+	lines := map[int]model.LineMetrics{1: {Hits: 1}}
+	result := &parsers.ParserResult{
+		ParserName: "GoCover",
+		FileCoverage: []parsers.FileCoverage{
+			{Path: "src/app.go", Lines: lines},
+			{Path: "util.go", Lines: lines},
+			{Path: "handler.go", Lines: lines},
+			{Path: "missing.go", Lines: lines},
+			{Path: "gen/types.pb.go", Lines: lines},
+		},
+		UnresolvedSourceFiles: []string{"missing.go", "dropped.go"},
+		SourceDirectory:       "/project",
+		ReportPattern:         "*.out",
+		ReportFile:            "/reports/coverage.out",
+	}
+
+	// Act
+	summaryTree, err := tree.NewBuilder("/project", filter, resolver).BuildTree([]*parsers.ParserResult{result})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, summaryTree.Metrics.LinesValid, "Only the exact and the fuzzy-matched file are included")
+
+	type diagnosticSummary struct {
+		kind model.DiagnosticKind
+		path string
+	}
+	var got []diagnosticSummary
+	for _, diagnostic := range summaryTree.Diagnostics {
+		assert.Equal(t, "/reports/coverage.out", diagnostic.Report)
+		got = append(got, diagnosticSummary{diagnostic.Kind, diagnostic.Path})
+	}
+	assert.Equal(t, []diagnosticSummary{
+		{model.DiagnosticUnresolved, "dropped.go"},
+		{model.DiagnosticUnresolved, "missing.go"},
+		{model.DiagnosticAmbiguous, "handler.go"},
+		{model.DiagnosticFuzzyMatch, "util.go"},
+		{model.DiagnosticFiltered, "gen/types.pb.go"},
+	}, got)
+
+	ambiguous := summaryTree.Diagnostics[2]
+	assert.Equal(t, []string{"/project/api/handler.go", "/project/web/handler.go"}, ambiguous.Candidates)
+	fuzzy := summaryTree.Diagnostics[3]
+	assert.Equal(t, "src/deep/util.go", fuzzy.ResolvedPath)
+
+	files := summaryTree.FilesByPath()
+	assert.Equal(t, "/project/src/deep/util.go", files["src/deep/util.go"].AbsolutePath, "The resolved path is kept on the node")
+}
+
+func TestBuilder_BuildTree_ReportModes(t *testing.T) {
+	mockFS := testutil.NewMockFilesystem("unix")
+	mockFS.AddFile("/project/app.go", "package app")
+	noFilter, err := filtering.NewDefaultFilter(nil, true)
+	require.NoError(t, err)
+	newResult := func(pattern, mode string) *parsers.ParserResult {
+		return &parsers.ParserResult{
+			FileCoverage:    []parsers.FileCoverage{{Path: "app.go", Lines: map[int]model.LineMetrics{1: {Hits: 1}}}},
+			SourceDirectory: "/project",
+			ReportPattern:   pattern,
+			CoverageMode:    mode,
+		}
+	}
+
+	summaryTree, err := tree.NewBuilder("/project", noFilter, utils.NewSourceResolver(mockFS, nil)).BuildTree([]*parsers.ParserResult{
+		newResult("unit.out", "set"),
+		newResult("lcov.info", ""),
+		newResult("*.out", "set"),
+		newResult("*.out", "count"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unit.out", "lcov.info", "*.out"}, summaryTree.ReportNames)
+	assert.Equal(t, []string{"set", "", ""}, summaryTree.ReportModes, "A report whose files disagree has no single mode")
+}
+
+func TestBuilder_BuildTree_MergesMethodsAcrossFormats(t *testing.T) {
+	mockFS := testutil.NewMockFilesystem("unix")
+	mockFS.AddFile("/project/TestClass.cs", "class TestClass {}")
+	noFilter, err := filtering.NewDefaultFilter(nil, true)
+	require.NoError(t, err)
+	lines := map[int]model.LineMetrics{10: {Hits: 1}, 11: {Hits: 1}, 21: {Hits: 0}, 22: {Hits: 0}}
+	lcovResult := &parsers.ParserResult{
+		ParserName: "LCOV",
+		FileCoverage: []parsers.FileCoverage{{Path: "TestClass.cs", Lines: lines, Methods: []model.MethodMetrics{
+			{Name: "System.Void Test.TestClass::SampleFunction()", StartLine: 9, EndLine: 11},
+			{Name: "System.Void Test.TestClass::OtherFunction()", StartLine: 20, EndLine: 22},
+		}}},
+		SourceDirectory: "/project",
+		ReportPattern:   "lcov.info",
+	}
+	coberturaResult := &parsers.ParserResult{
+		ParserName: "Cobertura",
+		FileCoverage: []parsers.FileCoverage{{Path: "TestClass.cs", Lines: lines, Methods: []model.MethodMetrics{
+			{Name: "SampleFunction()", StartLine: 10, EndLine: 11},
+			{Name: "<SampleFunction>b__0()", StartLine: 11, EndLine: 11},
+			{Name: "OtherFunction()", StartLine: 21, EndLine: 22},
+			{Name: "UnreportedByLcov()", StartLine: 30, EndLine: 32},
+		}}},
+		SourceDirectory: "/project",
+		ReportPattern:   "cobertura.xml",
+	}
+
+	summaryTree, err := tree.NewBuilder("/project", noFilter, utils.NewSourceResolver(mockFS, nil)).BuildTree([]*parsers.ParserResult{lcovResult, coberturaResult})
+
+	require.NoError(t, err)
+	var names []string
+	for _, method := range summaryTree.Root.Files["TestClass.cs"].Methods {
+		names = append(names, method.Name)
+	}
+	assert.Equal(t, []string{
+		"System.Void Test.TestClass::SampleFunction()",
+		"System.Void Test.TestClass::OtherFunction()",
+		"UnreportedByLcov()",
+	}, names, "Methods overlapping those of an earlier report are the same methods")
+
+	t.Run("Nested methods of one report are kept", func(t *testing.T) {
+		summaryTree, err := tree.NewBuilder("/project", noFilter, utils.NewSourceResolver(mockFS, nil)).BuildTree([]*parsers.ParserResult{coberturaResult})
+		require.NoError(t, err)
+		assert.Len(t, summaryTree.Root.Files["TestClass.cs"].Methods, 4)
+	})
+}
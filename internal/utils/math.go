@@ -63,3 +63,12 @@ func FormatPercentage(percentage float64, decimalPlaces int) string {
 	}
 	return fmt.Sprintf(fmt.Sprintf("%%.%df%%%%", decimalPlaces), percentage)
 }
+
+// FormatDelta formats a change in percentage points with an explicit sign.
+// Changes that round to zero are shown as positive.
+func FormatDelta(delta float64, decimalPlaces int) string {
+	if math.Abs(delta) < 0.5*math.Pow(10, -float64(decimalPlaces)) {
+		delta = 0
+	}
+	return fmt.Sprintf("%+.*f%%", decimalPlaces, delta)
+}
@@ -43,18 +43,93 @@ func walk(reader filereader.Reader, root string, fileNameToFind string, logger *
 	return "", nil
 }
 
+// MatchStrategy tells which strategy matched a report path to a source file.
+type MatchStrategy int
+
+const (
+	MatchAbsolute MatchStrategy = iota + 1
+	MatchDirectJoin
+	MatchSuffix
+	MatchSearch
+)
+
+// IsFuzzy reports whether the match was a guess based on part of the path,
+// which can pick the wrong file when several files share a name.
+func (s MatchStrategy) IsFuzzy() bool {
+	return s == MatchSuffix || s == MatchSearch
+}
+
+func (s MatchStrategy) String() string {
+	switch s {
+	case MatchAbsolute:
+		return "absolute path"
+	case MatchDirectJoin:
+		return "direct join"
+	case MatchSuffix:
+		return "path suffix"
+	case MatchSearch:
+		return "file name search"
+	default:
+		return "unknown"
+	}
+}
+
+// Resolution is a source file found for a report path.
+type Resolution struct {
+	Path     string
+	Strategy MatchStrategy
+}
+
 // FindFileInSourceDirs resolves a file path from a report against a list of source directories.
 func FindFileInSourceDirs(relativePath string, sourceDirs []string, reader filereader.Reader, logger *slog.Logger) (string, error) {
+	resolution, err := ResolveSourceFile(relativePath, sourceDirs, reader, logger)
+	return resolution.Path, err
+}
+
+// ResolveSourceFile is FindFileInSourceDirs, but also tells which strategy
+// found the file.
+func ResolveSourceFile(relativePath string, sourceDirs []string, reader filereader.Reader, logger *slog.Logger) (Resolution, error) {
 	// If a nil logger is passed, default to a discarded one to prevent panics.
 	if logger == nil {
 		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 	}
 
-	// Normalize path separators: replace backslashes with forward slashes
-	// This handles Windows-style paths in coverage reports even when running on Unix
-	// (coverage tools sometimes emit Windows paths like "src\app.go" even in cross-platform reports)
-	normalizedRelativePath := strings.ReplaceAll(relativePath, "\\", "/")
-	fileNameToFind := filepath.Base(normalizedRelativePath)
+	if resolution, ok := findExact(relativePath, sourceDirs, reader, logger); ok {
+		return resolution, nil
+	}
+	if resolution, ok := findBySuffix(relativePath, sourceDirs, reader, logger); ok {
+		return resolution, nil
+	}
+
+	// Strategy 4: Recursive Fallback Search
+	fileNameToFind := filepath.Base(normalizePath(relativePath))
+	logger.Debug("Strategy 4: Starting recursive search.", "filename", fileNameToFind)
+	for _, dir := range sourceDirs {
+		foundPath, err := walk(reader, filepath.Clean(dir), fileNameToFind, logger)
+		if err != nil {
+			return Resolution{}, fmt.Errorf("error during recursive search in '%s': %w", dir, err)
+		}
+		if foundPath != "" {
+			logger.Debug("Strategy 4: Success.", "foundPath", foundPath)
+			return Resolution{Path: foundPath, Strategy: MatchSearch}, nil
+		}
+	}
+
+	logger.Warn("All strategies failed to find file.", "relativePath", relativePath)
+	return Resolution{}, fmt.Errorf("file %q not found in any of the provided source directories", relativePath)
+}
+
+// normalizePath replaces backslashes with forward slashes. Coverage tools
+// sometimes emit Windows paths like "src\app.go" even in cross-platform
+// reports, so this is done regardless of the platform we run on.
+func normalizePath(p string) string {
+	return strings.ReplaceAll(p, "\\", "/")
+}
+
+// findExact applies the strategies that take the path as it is: the path
+// itself when it is absolute, and the path joined with each source directory.
+func findExact(relativePath string, sourceDirs []string, reader filereader.Reader, logger *slog.Logger) (Resolution, bool) {
+	normalizedRelativePath := normalizePath(relativePath)
 	logger.Debug("FindFileInSourceDirs starting", "relativePath", relativePath, "normalizedPath", normalizedRelativePath, "sourceDirs", sourceDirs)
 
 	// Strategy 1: Absolute Path Check
@@ -64,7 +139,7 @@ func FindFileInSourceDirs(relativePath string, sourceDirs []string, reader filer
 			logger.Debug("Strategy 1: Path is absolute, checking existence.", "path", pathToCheck)
 			if _, err := reader.Stat(pathToCheck); err == nil {
 				logger.Debug("Strategy 1: Success.", "foundPath", pathToCheck)
-				return pathToCheck, nil
+				return Resolution{Path: pathToCheck, Strategy: MatchAbsolute}, true
 			}
 		}
 	}
@@ -76,12 +151,19 @@ func FindFileInSourceDirs(relativePath string, sourceDirs []string, reader filer
 		logger.Debug("Strategy 2: Trying direct join.", "path", potentialPath)
 		if _, err := reader.Stat(potentialPath); err == nil {
 			logger.Debug("Strategy 2: Success.", "foundPath", potentialPath)
-			return potentialPath, nil
+			return Resolution{Path: potentialPath, Strategy: MatchDirectJoin}, true
 		}
 	}
 
+	return Resolution{}, false
+}
+
+// findBySuffix joins the trailing parts of the path with each source
+// directory, from the longest to the shortest, and returns the first that
+// exists.
+func findBySuffix(relativePath string, sourceDirs []string, reader filereader.Reader, logger *slog.Logger) (Resolution, bool) {
 	// Strategy 3: Suffix Matching
-	pathParts := strings.Split(normalizedRelativePath, "/")
+	pathParts := strings.Split(normalizePath(relativePath), "/")
 	if len(pathParts) > 1 {
 		for i := 1; i < len(pathParts); i++ {
 			suffix := strings.Join(pathParts[i:], "/")
@@ -91,25 +173,11 @@ func FindFileInSourceDirs(relativePath string, sourceDirs []string, reader filer
 				logger.Debug("Strategy 3: Trying suffix join.", "path", potentialPath)
 				if _, err := reader.Stat(potentialPath); err == nil {
 					logger.Debug("Strategy 3: Success.", "foundPath", potentialPath)
-					return potentialPath, nil
+					return Resolution{Path: potentialPath, Strategy: MatchSuffix}, true
 				}
 			}
 		}
 	}
 
-	// Strategy 4: Recursive Fallback Search
-	logger.Debug("Strategy 4: Starting recursive search.", "filename", fileNameToFind)
-	for _, dir := range sourceDirs {
-		foundPath, err := walk(reader, filepath.Clean(dir), fileNameToFind, logger)
-		if err != nil {
-			return "", fmt.Errorf("error during recursive search in '%s': %w", dir, err)
-		}
-		if foundPath != "" {
-			logger.Debug("Strategy 4: Success.", "foundPath", foundPath)
-			return foundPath, nil
-		}
-	}
-
-	logger.Warn("All strategies failed to find file.", "relativePath", relativePath)
-	return "", fmt.Errorf("file %q not found in any of the provided source directories", relativePath)
+	return Resolution{}, false
 }
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/IgorBayerl/nanovision/filereader"
+)
+
+// AmbiguousPathError is returned when a report path matches several files of
+// a source directory equally well, e.g. a bare "handler.go" in a repository
+// with many modules. Taking any one of them could attribute coverage to the
+// wrong file.
+type AmbiguousPathError struct {
+	Path       string
+	Candidates []string
+}
+
+func (e *AmbiguousPathError) Error() string {
+	return fmt.Sprintf("file %q is ambiguous, it matches %d files: %s", e.Path, len(e.Candidates), strings.Join(e.Candidates, ", "))
+}
+
+// SourceResolver resolves report paths to source files once per run. The
+// parsers and the tree builder are given the same resolver, so they share its
+// index and cached results. It wraps a filereader.Reader, which they also use
+// to read the files it finds.
+//
+// The absolute path and direct join strategies of FindFileInSourceDirs are
+// kept as they are. The suffix join and the recursive walk, which both take
+// the first file they come across, are replaced by an index of each source
+// directory, built on first use, that maps file names to their paths. Among
+// the files with the right name, the one sharing the longest trailing part
+// with the report path wins, and a tie is reported as ambiguous. Results,
+// including failures, are cached.
+type SourceResolver struct {
+	filereader.Reader
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	indexes map[string]*sourceIndex
+	cache   map[string]resolveResult
+}
+
+// sourceIndex maps the file names below a source directory to their paths,
+// relative to that directory and using forward slashes.
+type sourceIndex struct {
+	once   sync.Once
+	byName map[string][]string
+}
+
+type resolveResult struct {
+	resolution Resolution
+	err        error
+}
+
+// NewSourceResolver creates a resolver that reads the file system through reader.
+func NewSourceResolver(reader filereader.Reader, logger *slog.Logger) *SourceResolver {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	return &SourceResolver{
+		Reader:  reader,
+		logger:  logger,
+		indexes: make(map[string]*sourceIndex),
+		cache:   make(map[string]resolveResult),
+	}
+}
+
+// Resolve returns the path of the source file a report path refers to. It is
+// safe for concurrent use.
+func (r *SourceResolver) Resolve(relativePath string, sourceDirs []string) (Resolution, error) {
+	cacheKey := relativePath + "\x00" + strings.Join(sourceDirs, "\x00")
+	r.mu.Lock()
+	cached, ok := r.cache[cacheKey]
+	r.mu.Unlock()
+	if ok {
+		return cached.resolution, cached.err
+	}
+
+	resolution, err := r.resolve(relativePath, sourceDirs)
+
+	r.mu.Lock()
+	r.cache[cacheKey] = resolveResult{resolution: resolution, err: err}
+	r.mu.Unlock()
+	return resolution, err
+}
+
+func (r *SourceResolver) resolve(relativePath string, sourceDirs []string) (Resolution, error) {
+	if resolution, ok := findExact(relativePath, sourceDirs, r.Reader, r.logger); ok {
+		return resolution, nil
+	}
+
+	// Strategies 3 and 4: Index Lookup. A file the suffix join would find is
+	// in the index too, so it only wins when no other file matches as well.
+	reportParts := strings.Split(strings.Trim(normalizePath(relativePath), "/"), "/")
+	fileName := reportParts[len(reportParts)-1]
+	for _, dir := range sourceDirs {
+		dir = filepath.Clean(dir)
+		candidates := r.index(dir).byName[fileName]
+		if len(candidates) == 0 {
+			continue
+		}
+
+		best, bestScore := []string(nil), 0
+		for _, candidate := range candidates {
+			score := matchingTrailingParts(reportParts, strings.Split(candidate, "/"))
+			switch {
+			case score > bestScore:
+				best, bestScore = []string{candidate}, score
+			case score == bestScore:
+				best = append(best, candidate)
+			}
+		}
+
+		if len(best) > 1 {
+			ambiguous := &AmbiguousPathError{Path: relativePath}
+			for _, candidate := range best {
+				ambiguous.Candidates = append(ambiguous.Candidates, filepath.Join(dir, filepath.FromSlash(candidate)))
+			}
+			sort.Strings(ambiguous.Candidates)
+			r.logger.Warn("Report path matches several source files, it will not be resolved.", "relativePath", relativePath, "candidates", ambiguous.Candidates)
+			return Resolution{}, ambiguous
+		}
+
+		// When the whole relative path of the file ends the report path, the
+		// match is the one the suffix join would have made.
+		strategy := MatchSearch
+		if bestScore == len(strings.Split(best[0], "/")) {
+			strategy = MatchSuffix
+		}
+		foundPath := filepath.Join(dir, filepath.FromSlash(best[0]))
+		r.logger.Debug("Index lookup: Success.", "foundPath", foundPath, "strategy", strategy)
+		return Resolution{Path: foundPath, Strategy: strategy}, nil
+	}
+
+	r.logger.Warn("All strategies failed to find file.", "relativePath", relativePath)
+	return Resolution{}, fmt.Errorf("file %q not found in any of the provided source directories", relativePath)
+}
+
+// index returns the index of a source directory, building it on first use.
+func (r *SourceResolver) index(dir string) *sourceIndex {
+	r.mu.Lock()
+	idx, ok := r.indexes[dir]
+	if !ok {
+		idx = &sourceIndex{byName: make(map[string][]string)}
+		r.indexes[dir] = idx
+	}
+	r.mu.Unlock()
+
+	idx.once.Do(func() {
+		r.logger.Debug("Indexing source directory.", "dir", dir)
+		r.indexDir(idx, dir, "")
+		r.logger.Debug("Indexed source directory.", "dir", dir, "fileNames", len(idx.byName))
+	})
+	return idx
+}
+
+// indexDir adds the files below dir to the index. Directories that cannot be
+// read are skipped, as are ".git" directories, which never hold sources.
+func (r *SourceResolver) indexDir(idx *sourceIndex, dir string, relativeDir string) {
+	entries, err := r.ReadDir(dir)
+	if err != nil {
+		r.logger.Debug("Cannot read directory, it will not be indexed.", "dir", dir, "error", err)
+		return
+	}
+
+	for _, entry := range entries {
+		relativePath := entry.Name()
+		if relativeDir != "" {
+			relativePath = relativeDir + "/" + entry.Name()
+		}
+		if entry.IsDir() {
+			if entry.Name() != ".git" {
+				r.indexDir(idx, filepath.Join(dir, entry.Name()), relativePath)
+			}
+			continue
+		}
+		idx.byName[entry.Name()] = append(idx.byName[entry.Name()], relativePath)
+	}
+}
+
+// matchingTrailingParts counts how many trailing path segments two paths share.
+func matchingTrailingParts(a, b []string) int {
+	count := 0
+	for i, j := len(a)-1, len(b)-1; i >= 0 && j >= 0 && a[i] == b[j]; i, j = i-1, j-1 {
+		count++
+	}
+	return count
+}
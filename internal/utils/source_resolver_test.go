@@ -0,0 +1,186 @@
+package utils_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/IgorBayerl/nanovision/internal/testutil"
+	"github.com/IgorBayerl/nanovision/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingFilesystem counts the directory reads of the mock filesystem.
+type countingFilesystem struct {
+	*testutil.MockFilesystem
+	readDirCalls int
+}
+
+func (c *countingFilesystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	c.readDirCalls++
+	return c.MockFilesystem.ReadDir(name)
+}
+
+func TestSourceResolver_Resolve(t *testing.T) {
+	isWindows := runtime.GOOS == "windows"
+	p := func(path string) string {
+		if isWindows {
+			return filepath.FromSlash("C:" + path)
+		}
+		return path
+	}
+
+	platform := "unix"
+	if isWindows {
+		platform = "windows"
+	}
+
+	testCases := []struct {
+		name            string
+		mockFiles       []string
+		sourceDirs      []string
+		relativePath    string
+		expectedPath    string
+		expectStrategy  utils.MatchStrategy
+		expectError     bool
+		expectAmbiguous bool
+	}{
+		{
+			name:           "Direct join is used before the index",
+			mockFiles:      []string{p("/project/src/app.go"), p("/project/lib/src/app.go")},
+			sourceDirs:     []string{p("/project")},
+			relativePath:   "src/app.go",
+			expectedPath:   p("/project/src/app.go"),
+			expectStrategy: utils.MatchDirectJoin,
+		},
+		{
+			name:           "Filename only - unique file is found through the index",
+			mockFiles:      []string{p("/project/a/b/c/deep.go")},
+			sourceDirs:     []string{p("/project")},
+			relativePath:   "deep.go",
+			expectedPath:   p("/project/a/b/c/deep.go"),
+			expectStrategy: utils.MatchSearch,
+		},
+		{
+			name:           "Longest matching suffix wins over other files with the same name",
+			mockFiles:      []string{p("/project/services/user/handler.go"), p("/project/api/order/handler.go")},
+			sourceDirs:     []string{p("/project")},
+			relativePath:   "/ci/build/monorepo/user/handler.go",
+			expectedPath:   p("/project/services/user/handler.go"),
+			expectStrategy: utils.MatchSearch,
+		},
+		{
+			name:           "Suffix match is accepted when no other file matches as well",
+			mockFiles:      []string{p("/project/src/app.go"), p("/project/lib/app.go")},
+			sourceDirs:     []string{p("/project")},
+			relativePath:   "/ci/checkout/src/app.go",
+			expectedPath:   p("/project/src/app.go"),
+			expectStrategy: utils.MatchSuffix,
+		},
+		{
+			name:           "Suffix join defers to a longer match in the index",
+			mockFiles:      []string{p("/project/handler.go"), p("/project/services/user/handler.go")},
+			sourceDirs:     []string{p("/project")},
+			relativePath:   "/ci/user/handler.go",
+			expectedPath:   p("/project/services/user/handler.go"),
+			expectStrategy: utils.MatchSearch,
+		},
+		{
+			name:            "Suffix match with an equally good match elsewhere is ambiguous",
+			mockFiles:       []string{p("/project/src/app.go"), p("/project/lib/src/app.go")},
+			sourceDirs:      []string{p("/project")},
+			relativePath:    "/ci/checkout/src/app.go",
+			expectError:     true,
+			expectAmbiguous: true,
+		},
+		{
+			name:            "Same filename with equally good matches is ambiguous",
+			mockFiles:       []string{p("/project/services/user/handler.go"), p("/project/api/user/handler.go")},
+			sourceDirs:      []string{p("/project")},
+			relativePath:    "handler.go",
+			expectError:     true,
+			expectAmbiguous: true,
+		},
+		{
+			name:           "First source directory with a match wins",
+			mockFiles:      []string{p("/project/api/nested/config.go"), p("/project/lib/nested/config.go")},
+			sourceDirs:     []string{p("/project/api"), p("/project/lib")},
+			relativePath:   "config.go",
+			expectedPath:   p("/project/api/nested/config.go"),
+			expectStrategy: utils.MatchSearch,
+		},
+		{
+			name:         "Files under .git are not indexed",
+			mockFiles:    []string{p("/project/.git/hooks/pre-commit.go")},
+			sourceDirs:   []string{p("/project")},
+			relativePath: "pre-commit.go",
+			expectError:  true,
+		},
+		{
+			name:         "File does not exist anywhere",
+			mockFiles:    []string{p("/project/src/app.go")},
+			sourceDirs:   []string{p("/project")},
+			relativePath: "missing.go",
+			expectError:  true,
+		},
+	}
+
+	nopLogger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockFS := testutil.NewMockFilesystem(platform)
+			for _, path := range tc.mockFiles {
+				mockFS.AddFile(path, "content")
+			}
+			resolver := utils.NewSourceResolver(mockFS, nopLogger)
+
+			// Act
+			resolution, err := resolver.Resolve(tc.relativePath, tc.sourceDirs)
+
+			// Assert
+			if tc.expectError {
+				require.Error(t, err)
+				var ambiguous *utils.AmbiguousPathError
+				assert.Equal(t, tc.expectAmbiguous, errors.As(err, &ambiguous))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Clean(tc.expectedPath), filepath.Clean(resolution.Path))
+			assert.Equal(t, tc.expectStrategy, resolution.Strategy)
+		})
+	}
+}
+
+func TestSourceResolver_IndexesOnceAndCachesResults(t *testing.T) {
+	platform := "unix"
+	root := "/project"
+	if runtime.GOOS == "windows" {
+		platform = "windows"
+		root = `C:\project`
+	}
+
+	mockFS := &countingFilesystem{MockFilesystem: testutil.NewMockFilesystem(platform)}
+	mockFS.AddFile(filepath.Join(root, "a", "one.go"), "content")
+	mockFS.AddFile(filepath.Join(root, "b", "two.go"), "content")
+	resolver := utils.NewSourceResolver(mockFS, nil)
+
+	first, err := resolver.Resolve("one.go", []string{root})
+	require.NoError(t, err)
+	readsAfterIndexing := mockFS.readDirCalls
+	assert.Equal(t, 3, readsAfterIndexing, "The root and its two subdirectories should be read once")
+
+	_, err = resolver.Resolve("two.go", []string{root})
+	require.NoError(t, err)
+	again, err := resolver.Resolve("one.go", []string{root})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, again)
+	assert.Equal(t, readsAfterIndexing, mockFS.readDirCalls, "The index should be shared by later lookups")
+}
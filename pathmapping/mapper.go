@@ -0,0 +1,117 @@
+// Package pathmapping rewrites the file paths found in coverage reports before
+// they are resolved against the source directories.
+//
+// Reports produced on CI agents, inside containers or on other machines record
+// paths under prefixes that do not exist locally (e.g. "/home/runner/work/app"
+// or "D:\a\app"). A mapping turns them into paths that do, which is both faster
+// and more reliable than the suffix-matching fallback of the path resolver.
+//
+// # Mapping Syntax
+//
+// Each mapping has a "from" and a "to" value:
+//
+//   - Prefix (default): "from" is a path prefix that is replaced by "to". It
+//     only matches whole path segments, so "/build/app" does not match
+//     "/build/application". A "to" of "." or "" leaves a path relative to the
+//     source directory.
+//
+//   - Regex: with "regex: true", "from" is a regular expression and "to" its
+//     replacement, which may reference groups as $1 or ${name}.
+//
+// Backslashes are treated as forward slashes on both sides, so Windows paths
+// can be written either way. Mappings are tried in order; the first one that
+// matches a path wins.
+package pathmapping
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Rule is a single mapping as written in the configuration file.
+type Rule struct {
+	From  string `yaml:"from"`
+	To    string `yaml:"to"`
+	Regex bool   `yaml:"regex"`
+}
+
+// Mapper applies an ordered list of rules to report paths.
+type Mapper struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	prefix  string
+	pattern *regexp.Regexp
+	to      string
+}
+
+// New validates and compiles the given rules. A Mapper without rules leaves
+// every path unchanged.
+func New(rules []Rule) (*Mapper, error) {
+	mapper := &Mapper{}
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.From) == "" {
+			return nil, fmt.Errorf("path mapping %d: 'from' must not be empty", i+1)
+		}
+
+		compiled := compiledRule{to: normalize(rule.To)}
+		if rule.Regex {
+			pattern, err := regexp.Compile(rule.From)
+			if err != nil {
+				return nil, fmt.Errorf("path mapping %d: invalid regex '%s': %w", i+1, rule.From, err)
+			}
+			compiled.pattern = pattern
+		} else {
+			compiled.prefix = strings.TrimSuffix(normalize(rule.From), "/")
+		}
+		mapper.rules = append(mapper.rules, compiled)
+	}
+	return mapper, nil
+}
+
+// Map returns the path rewritten by the first matching rule, or the path
+// unchanged when no rule matches.
+func (m *Mapper) Map(filePath string) string {
+	if m == nil || len(m.rules) == 0 {
+		return filePath
+	}
+
+	normalized := normalize(filePath)
+	for _, rule := range m.rules {
+		if mapped, ok := rule.apply(normalized); ok {
+			return mapped
+		}
+	}
+	return filePath
+}
+
+// apply rewrites a normalized path if the rule matches it.
+func (r compiledRule) apply(filePath string) (string, bool) {
+	if r.pattern != nil {
+		if !r.pattern.MatchString(filePath) {
+			return "", false
+		}
+		return r.pattern.ReplaceAllString(filePath, r.to), true
+	}
+
+	rest, ok := strings.CutPrefix(filePath, r.prefix)
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/") && r.prefix != "") {
+		return "", false
+	}
+	rest = strings.TrimPrefix(rest, "/")
+
+	switch r.to {
+	case "", ".":
+		return rest, true
+	default:
+		return path.Join(r.to, rest), true
+	}
+}
+
+// normalize converts backslashes to forward slashes.
+func normalize(p string) string {
+	return strings.ReplaceAll(strings.TrimSpace(p), "\\", "/")
+}
@@ -0,0 +1,93 @@
+package pathmapping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMap tests how paths are rewritten by prefix and regex rules.
+func TestMap(t *testing.T) {
+	testCases := []struct {
+		name         string
+		rules        []Rule
+		inputPath    string
+		expectedPath string
+	}{
+		{
+			name:         "NoRules_ReturnsPathUnchanged",
+			inputPath:    "/home/runner/work/app/src/main.go",
+			expectedPath: "/home/runner/work/app/src/main.go",
+		},
+		{
+			name:         "PrefixToDot_ReturnsRelativePath",
+			rules:        []Rule{{From: "/home/runner/work/app", To: "."}},
+			inputPath:    "/home/runner/work/app/src/main.go",
+			expectedPath: "src/main.go",
+		},
+		{
+			name:         "PrefixWithTrailingSlash_ReturnsRelativePath",
+			rules:        []Rule{{From: "/build/src/", To: ""}},
+			inputPath:    "/build/src/lib/math.c",
+			expectedPath: "lib/math.c",
+		},
+		{
+			name:         "PrefixToDirectory_ReplacesPrefix",
+			rules:        []Rule{{From: "/build/src", To: "/home/dev/project"}},
+			inputPath:    "/build/src/lib/math.c",
+			expectedPath: "/home/dev/project/lib/math.c",
+		},
+		{
+			name:         "PrefixMatchesOnlyWholeSegments",
+			rules:        []Rule{{From: "/build/app", To: "."}},
+			inputPath:    "/build/application/main.go",
+			expectedPath: "/build/application/main.go",
+		},
+		{
+			name:         "WindowsPath_MatchesEitherSeparator",
+			rules:        []Rule{{From: `D:\a\app\app`, To: "."}},
+			inputPath:    `D:\a\app\app\src\Calculator.cs`,
+			expectedPath: "src/Calculator.cs",
+		},
+		{
+			name: "FirstMatchingRuleWins",
+			rules: []Rule{
+				{From: "/build/src/module-a", To: "modules/a"},
+				{From: "/build/src", To: "."},
+			},
+			inputPath:    "/build/src/module-a/util.go",
+			expectedPath: "modules/a/util.go",
+		},
+		{
+			name:         "Regex_ExpandsGroups",
+			rules:        []Rule{{From: `^/tmp/build-[0-9]+/(.*)$`, To: "$1", Regex: true}},
+			inputPath:    "/tmp/build-1234/pkg/server.go",
+			expectedPath: "pkg/server.go",
+		},
+		{
+			name:         "Regex_NonMatchingPath_ReturnsPathUnchanged",
+			rules:        []Rule{{From: `^/tmp/build-[0-9]+/(.*)$`, To: "$1", Regex: true}},
+			inputPath:    "/src/pkg/server.go",
+			expectedPath: "/src/pkg/server.go",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapper, err := New(tc.rules)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedPath, mapper.Map(tc.inputPath))
+		})
+	}
+}
+
+// TestNew_InvalidRules tests that invalid rules are rejected.
+func TestNew_InvalidRules(t *testing.T) {
+	_, err := New([]Rule{{From: "", To: "."}})
+	assert.Error(t, err, "An empty 'from' should be rejected")
+
+	_, err = New([]Rule{{From: "([a-z", To: "$1", Regex: true}})
+	assert.Error(t, err, "An invalid regex should be rejected")
+}
@@ -0,0 +1,76 @@
+import { AlertCircle, AlertTriangle, Filter, Search } from 'lucide-react'
+import type { DiagnosticFile, DiagnosticGroup } from '@/types/summary'
+import { Card, CardContent, CardHeader, CardTitle } from '@/ui/card'
+
+// Unresolved and ambiguous files are missing from the report, so they are
+// flagged as errors. Fuzzy matches are included but may be wrong, and filtered
+// files were left out on purpose.
+const KindIcon = ({ kind }: { kind: string }) => {
+    switch (kind) {
+        case 'unresolved':
+        case 'ambiguous':
+            return <AlertCircle className="h-4 w-4 text-uncovered" />
+        case 'fuzzy-match':
+            return <AlertTriangle className="h-4 w-4 text-partial" />
+        case 'filtered':
+            return <Filter className="h-4 w-4 text-muted-foreground" />
+        default:
+            return <Search className="h-4 w-4 text-muted-foreground" />
+    }
+}
+
+const reportName = (report: string) => report.split(/[\\/]/).pop() || report
+
+const DiagnosticRow = ({ file }: { file: DiagnosticFile }) => (
+    <li className="flex flex-col gap-0.5 px-2 py-1 text-sm hover:bg-accent/50">
+        <div className="flex items-baseline justify-between gap-4">
+            <span className="break-all font-mono text-foreground">
+                {file.path}
+                {file.resolvedPath && <span className="text-muted-foreground"> → {file.resolvedPath}</span>}
+            </span>
+            <span className="shrink-0 font-mono text-muted-foreground text-xs" title={file.report}>
+                {reportName(file.report)}
+            </span>
+        </div>
+        {file.detail && <span className="text-muted-foreground text-xs">{file.detail}</span>}
+        {file.candidates && file.candidates.length > 0 && (
+            <ul className="ml-4 list-disc text-muted-foreground text-xs">
+                {file.candidates.map((candidate) => (
+                    <li key={candidate} className="break-all font-mono">
+                        {candidate}
+                    </li>
+                ))}
+            </ul>
+        )}
+    </li>
+)
+
+export default function DiagnosticsCard({ groups }: { groups: DiagnosticGroup[] }) {
+    return (
+        <Card className="rounded-md">
+            <CardHeader>
+                <CardTitle className="text-lg">Diagnostics</CardTitle>
+            </CardHeader>
+            <CardContent className="flex flex-col gap-2">
+                {groups.map((group) => (
+                    <details
+                        key={group.kind}
+                        className="rounded-md border border-border"
+                        open={group.kind !== 'filtered'}
+                    >
+                        <summary className="flex cursor-pointer items-center gap-2 px-2 py-1 font-medium text-sm">
+                            <KindIcon kind={group.kind} />
+                            {group.title}
+                            <span className="text-muted-foreground">({group.files.length})</span>
+                        </summary>
+                        <ul className="divide-y border-border border-t">
+                            {group.files.map((file) => (
+                                <DiagnosticRow key={`${file.report}:${file.path}`} file={file} />
+                            ))}
+                        </ul>
+                    </details>
+                ))}
+            </CardContent>
+        </Card>
+    )
+}
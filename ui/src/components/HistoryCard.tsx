@@ -0,0 +1,109 @@
+import type { HistoryPoint } from '@/types/summary'
+import { Card, CardContent, CardHeader, CardTitle } from '@/ui/card'
+
+type HistoryMetric = 'lineCoverage' | 'branchCoverage' | 'methodCoverage'
+
+const METRICS: { key: HistoryMetric; label: string }[] = [
+    { key: 'lineCoverage', label: 'Line coverage' },
+    { key: 'branchCoverage', label: 'Branch coverage' },
+    { key: 'methodCoverage', label: 'Method coverage' },
+]
+
+const WIDTH = 240
+const HEIGHT = 48
+const PADDING = 4
+
+const formatPercentage = (value: number) => `${value.toFixed(2)}%`
+
+const formatDelta = (value: number) => `${value >= 0 ? '+' : ''}${value.toFixed(2)}%`
+
+// A run is named by its tag, then its commit, then its date, e.g. in the
+// tooltips of the chart points.
+const describeRun = (point: HistoryPoint, isCurrent: boolean) => {
+    const when = new Date(point.timestamp).toLocaleString()
+    if (isCurrent) return `Current run, ${when}`
+    const name = point.tag || (point.commit ? `commit ${point.commit}` : '')
+    return name ? `${name}, ${when}` : when
+}
+
+// Runs that did not measure the metric leave a gap on the x axis instead of
+// being drawn as 0%, so that the line only joins measured runs.
+const Sparkline = ({ points, metric }: { points: HistoryPoint[]; metric: HistoryMetric }) => {
+    const step = points.length > 1 ? (WIDTH - 2 * PADDING) / (points.length - 1) : 0
+    const y = (value: number) => HEIGHT - PADDING - (value / 100) * (HEIGHT - 2 * PADDING)
+    const measured = points.flatMap((point, index) => {
+        const value = point[metric]
+        return value === undefined ? [] : [{ x: PADDING + index * step, y: y(value), value, point, index }]
+    })
+    const path = measured.map((p, i) => `${i === 0 ? 'M' : 'L'}${p.x.toFixed(1)},${p.y.toFixed(1)}`).join(' ')
+
+    return (
+        <svg viewBox={`0 0 ${WIDTH} ${HEIGHT}`} className="h-12 w-full text-primary" preserveAspectRatio="none">
+            <title>{`${METRICS.find((m) => m.key === metric)?.label} over ${points.length} runs`}</title>
+            <line
+                x1={PADDING}
+                x2={WIDTH - PADDING}
+                y1={y(0)}
+                y2={y(0)}
+                className="stroke-border"
+                strokeWidth={1}
+            />
+            <path d={path} fill="none" stroke="currentColor" strokeWidth={1.5} />
+            {measured.map((p) => (
+                <circle key={p.index} cx={p.x} cy={p.y} r={2.5} fill="currentColor">
+                    <title>{`${describeRun(p.point, p.index === points.length - 1)}: ${formatPercentage(p.value)}`}</title>
+                </circle>
+            ))}
+        </svg>
+    )
+}
+
+export default function HistoryCard({ points }: { points: HistoryPoint[] }) {
+    const current = points[points.length - 1]
+    const previous = points[points.length - 2]
+    const metrics = METRICS.filter((metric) => current[metric.key] !== undefined)
+    if (metrics.length === 0) return null
+
+    return (
+        <Card className="rounded-md">
+            <CardHeader>
+                <CardTitle className="text-lg">Coverage Trend</CardTitle>
+            </CardHeader>
+            <CardContent className="grid grid-cols-1 gap-4 md:grid-cols-3">
+                {metrics.map(({ key, label }) => {
+                    const value = current[key] as number
+                    const before = previous[key]
+                    const delta = before === undefined ? undefined : value - before
+                    return (
+                        <div key={key} className="flex flex-col gap-1">
+                            <div className="flex items-baseline justify-between gap-2 text-sm">
+                                <span className="font-medium">{label}</span>
+                                <span className="font-mono">
+                                    {formatPercentage(value)}
+                                    {delta !== undefined && (
+                                        <span
+                                            className={
+                                                delta > 0
+                                                    ? 'ml-1 text-covered'
+                                                    : delta < 0
+                                                      ? 'ml-1 text-uncovered'
+                                                      : 'ml-1 text-muted-foreground'
+                                            }
+                                            title={`Compared with ${describeRun(previous, false)}`}
+                                        >
+                                            ({formatDelta(delta)})
+                                        </span>
+                                    )}
+                                </span>
+                            </div>
+                            <Sparkline points={points} metric={key} />
+                        </div>
+                    )
+                })}
+                <p className="text-muted-foreground text-xs md:col-span-3">
+                    {points.length} runs recorded, compared with {describeRun(previous, false)}.
+                </p>
+            </CardContent>
+        </Card>
+    )
+}
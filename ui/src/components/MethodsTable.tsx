@@ -1,15 +1,17 @@
 import { useMemo } from 'react'
-import { camelCaseToTitleCase } from '@/lib/utils'
-import type { Method, MetricDefinitions } from '@/types/summary'
+import { camelCaseToTitleCase, describeRiskBand } from '@/lib/utils'
+import type { Method, MetricDefinitions, RiskThresholds } from '@/types/summary'
 import { Card, CardContent, CardHeader, CardTitle } from '@/ui/card'
 import { StatusIcon } from './MetricCard'
 
 export default function MethodsTable({
     methods,
     metricDefinitions,
+    riskThresholds,
 }: {
     methods: Method[]
     metricDefinitions: MetricDefinitions
+    riskThresholds?: RiskThresholds
 }) {
     const handleGoToLine = (lineNumber: number) => {
         const selector = `[data-line-number="${lineNumber}"]`
@@ -31,13 +33,15 @@ export default function MethodsTable({
         if (!methods || methods.length === 0) return []
         return Object.keys(methods[0].metrics).map((id) => {
             const def = metricDefinitions[id]
+            const band = riskThresholds?.[id]
             return {
                 id,
                 label: def?.label ?? camelCaseToTitleCase(id),
                 shortLabel: def?.shortLabel ?? camelCaseToTitleCase(id),
+                bandDescription: band ? describeRiskBand(band) : undefined,
             }
         })
-    }, [methods, metricDefinitions])
+    }, [methods, metricDefinitions, riskThresholds])
 
     if (!methods || methods.length === 0) {
         return null
@@ -60,6 +64,7 @@ export default function MethodsTable({
                                 <th
                                     key={mc.id}
                                     className="whitespace-nowrap px-4 py-2 text-right text-muted-foreground"
+                                    title={mc.bandDescription}
                                 >
                                     {mc.shortLabel}
                                 </th>
@@ -1,5 +1,6 @@
 import { AlertCircle, AlertTriangle, ShieldCheck } from 'lucide-react'
-import type { CoverageDetail, MetricDefinition, RiskLevel } from '@/types/summary'
+import { describeRiskBand } from '@/lib/utils'
+import type { CoverageDetail, MetricDefinition, RiskBand, RiskLevel } from '@/types/summary'
 import { Card, CardContent, CardHeader, CardTitle } from '@/ui/card'
 import { Progress } from '@/ui/progress'
 
@@ -22,11 +23,13 @@ export default function MetricCard({
     details,
     status,
     definition,
+    band,
 }: {
     label: string
     details: CoverageDetail | undefined
     status?: RiskLevel
     definition?: MetricDefinition
+    band?: RiskBand
 }) {
     const pct = details ? Math.max(0, Math.min(100, Math.round(details.percentage))) : undefined
 
@@ -34,7 +37,11 @@ export default function MetricCard({
         <Card className="flex h-full w-full flex-col rounded-md">
             <CardHeader className="flex flex-row items-center justify-between">
                 <CardTitle className="text-lg">{label}</CardTitle>
-                {status && <StatusIcon status={status} />}
+                {status && (
+                    <span title={band ? describeRiskBand(band) : undefined}>
+                        <StatusIcon status={status} />
+                    </span>
+                )}
             </CardHeader>
             <CardContent className="flex flex-grow gap-4">
                 <div className="flex flex-col items-center">
@@ -44,6 +51,7 @@ export default function MetricCard({
                     <div className="mt-2 w-full max-w-[100px]">
                         <Progress value={pct} indicatorClassName="bg-primary" />
                     </div>
+                    {band && <div className="mt-2 text-muted-foreground text-xs">{describeRiskBand(band)}</div>}
                 </div>
                 <div className="flex flex-1 flex-col divide-y">
                     {details && definition ? (
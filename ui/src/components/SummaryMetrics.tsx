@@ -1,7 +1,7 @@
 import InfoCard from '@/components/InfoCard'
 import MetricCard from '@/components/MetricCard'
 import { camelCaseToTitleCase } from '@/lib/utils'
-import type { CoverageDetail, MetadataItem, MetricDefinitions, Totals } from '@/types/summary'
+import type { CoverageDetail, MetadataItem, MetricDefinitions, RiskThresholds, Totals } from '@/types/summary'
 
 type SummaryMetricsProps = {
     info?: {
@@ -11,9 +11,16 @@ type SummaryMetricsProps = {
     metrics: Totals
     metricOrder: string[]
     metricDefinitions: MetricDefinitions
+    riskThresholds?: RiskThresholds
 }
 
-export default function SummaryMetrics({ info, metrics, metricOrder, metricDefinitions }: SummaryMetricsProps) {
+export default function SummaryMetrics({
+    info,
+    metrics,
+    metricOrder,
+    metricDefinitions,
+    riskThresholds,
+}: SummaryMetricsProps) {
     return (
         <div className="flex flex-wrap gap-4">
             {info && info.items.length > 0 && (
@@ -30,7 +37,13 @@ export default function SummaryMetrics({ info, metrics, metricOrder, metricDefin
 
                 return (
                     <div key={metricId} className="min-w-sm flex-grow lg:max-w-1/2">
-                        <MetricCard label={label} details={metricDetails} status={status} definition={definition} />
+                        <MetricCard
+                            label={label}
+                            details={metricDetails}
+                            status={status}
+                            definition={definition}
+                            band={riskThresholds?.[metricId]}
+                        />
                     </div>
                 )
             })}
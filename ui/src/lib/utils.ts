@@ -1,5 +1,6 @@
 import { type ClassValue, clsx } from 'clsx'
 import { twMerge } from 'tailwind-merge'
+import type { RiskBand } from '@/types/summary'
 
 export function cn(...inputs: ClassValue[]) {
     return twMerge(clsx(inputs))
@@ -13,3 +14,14 @@ export function camelCaseToTitleCase(text: string): string {
     const result = text.replace(/([A-Z])/g, ' $1')
     return result.charAt(0).toUpperCase() + result.slice(1)
 }
+
+/**
+ * Describes the configured risk band of a metric.
+ * Example: { safe: 80, warning: 60 } -> "Safe ≥ 80%, warning ≥ 60%"
+ */
+export function describeRiskBand(band: RiskBand): string {
+    if (band.lowerIsBetter) {
+        return `Safe ≤ ${band.safe}, warning ≤ ${band.warning}`
+    }
+    return `Safe ≥ ${band.safe}%, warning ≥ ${band.warning}%`
+}
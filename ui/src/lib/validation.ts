@@ -73,6 +73,42 @@ const metricDefinitionSchema = z.object({
     subMetrics: z.array(subMetricSchema),
 })
 
+// The configured risk band of each metric, keyed by metric ID
+const riskThresholdsSchema = z.record(
+    z.string(),
+    z.object({
+        safe: z.number(),
+        warning: z.number(),
+        lowerIsBetter: z.boolean().optional(),
+    }),
+)
+
+// Schema for the coverage of each recorded run, oldest first
+const historyPointSchema = z.object({
+    tag: z.string().optional(),
+    timestamp: z.string(),
+    commit: z.string().optional(),
+    lineCoverage: z.number().optional(),
+    branchCoverage: z.number().optional(),
+    methodCoverage: z.number().optional(),
+})
+
+// Schemas for the report files that were lost, ambiguous, fuzzy-matched or
+// filtered while building the tree, grouped by kind
+const diagnosticFileSchema = z.object({
+    path: z.string(),
+    report: z.string(),
+    resolvedPath: z.string().optional(),
+    candidates: z.array(z.string()).optional(),
+    detail: z.string().optional(),
+})
+
+const diagnosticGroupSchema = z.object({
+    kind: z.string(),
+    title: z.string(),
+    files: z.array(diagnosticFileSchema),
+})
+
 export const summaryV1Schema = z.object({
     schemaVersion: z.literal(1, { message: 'This report requires schemaVersion 1.' }),
     generatedAt: z
@@ -83,7 +119,10 @@ export const summaryV1Schema = z.object({
     totals: totalsSchema,
     tree: z.array(fileNodeSchema),
     metricDefinitions: z.record(z.string(), metricDefinitionSchema),
+    riskThresholds: riskThresholdsSchema.optional(),
     metadata: z.array(metadataItemSchema).optional(),
+    history: z.array(historyPointSchema).optional(),
+    diagnostics: z.array(diagnosticGroupSchema).optional(),
 })
 
 export type SummaryV1 = z.infer<typeof summaryV1Schema>
@@ -142,6 +181,7 @@ export const detailsV1Schema = z.object({
     fileName: z.string(),
     totals: totalsSchema,
     metricDefinitions: z.record(z.string(), metricDefinitionSchema),
+    riskThresholds: riskThresholdsSchema.optional(),
     lines: z.array(lineDetailsSchema),
     metadata: z.array(metadataItemSchema).optional(),
     methods: z.array(methodSchema).optional(),
@@ -67,6 +67,7 @@ export default function DetailsPage({ data: rawData }: { data: unknown }) {
                         metrics={validatedData.totals}
                         metricOrder={metricKeys}
                         metricDefinitions={validatedData.metricDefinitions}
+                        riskThresholds={validatedData.riskThresholds}
                     />
                     {validatedData.reports && validatedData.reports.length > 0 && (
                         <ReportsSelector
@@ -79,6 +80,7 @@ export default function DetailsPage({ data: rawData }: { data: unknown }) {
                         <MethodsTable
                             methods={validatedData.methods}
                             metricDefinitions={validatedData.metricDefinitions}
+                            riskThresholds={validatedData.riskThresholds}
                         />
                     )}
                     <SourceCodeViewer
@@ -1,5 +1,7 @@
 import { useMemo } from 'react'
+import DiagnosticsCard from '@/components/DiagnosticsCard'
 import FileExplorer from '@/components/FileExplorer'
+import HistoryCard from '@/components/HistoryCard'
 import Layout from '@/components/Layout'
 import SummaryMetrics from '@/components/SummaryMetrics'
 import ValidationAlerts from '@/components/ValidationAlerts'
@@ -62,7 +64,14 @@ export default function SummaryPage({ data: rawData }: { data: unknown }) {
                         metrics={validatedData.totals}
                         metricOrder={metricKeys}
                         metricDefinitions={validatedData.metricDefinitions}
+                        riskThresholds={validatedData.riskThresholds}
                     />
+                    {validatedData.history && validatedData.history.length > 1 && (
+                        <HistoryCard points={validatedData.history} />
+                    )}
+                    {validatedData.diagnostics && validatedData.diagnostics.length > 0 && (
+                        <DiagnosticsCard groups={validatedData.diagnostics} />
+                    )}
                     <FileExplorer
                         tree={validatedData.tree}
                         availableMetrics={metricKeys}
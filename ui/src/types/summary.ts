@@ -52,6 +52,41 @@ export type MetricDefinition = {
 
 export type MetricDefinitions = Record<string, MetricDefinition>
 
+// The configured band of a metric. Coverage is safe from `safe` up and a warning
+// from `warning` up; with `lowerIsBetter` (complexity) the comparisons are reversed.
+export type RiskBand = {
+    safe: number
+    warning: number
+    lowerIsBetter?: boolean
+}
+
+export type RiskThresholds = Record<string, RiskBand>
+
+// The coverage of one run, oldest first with the current run last. Metrics that
+// were not measured in a run are missing.
+export interface HistoryPoint {
+    tag?: string
+    timestamp: string
+    commit?: string
+    lineCoverage?: number
+    branchCoverage?: number
+    methodCoverage?: number
+}
+
+export interface DiagnosticFile {
+    path: string
+    report: string
+    resolvedPath?: string
+    candidates?: string[]
+    detail?: string
+}
+
+export interface DiagnosticGroup {
+    kind: string
+    title: string
+    files: DiagnosticFile[]
+}
+
 export interface SummaryV1 {
     schemaVersion: number
     generatedAt: string
@@ -60,7 +95,10 @@ export interface SummaryV1 {
     totals: Totals
     tree: FileNode[]
     metricDefinitions: MetricDefinitions
+    riskThresholds?: RiskThresholds
     metadata?: MetadataItem[]
+    history?: HistoryPoint[]
+    diagnostics?: DiagnosticGroup[]
 }
 
 export type RiskFilter = 'all' | 'danger' | 'warning' | 'safe'
@@ -124,6 +162,7 @@ export interface DetailsV1 {
     fileName: string
     totals: Totals
     metricDefinitions: MetricDefinitions
+    riskThresholds?: RiskThresholds
     lines: LineDetails[]
     metadata?: MetadataItem[]
     methods?: Method[]